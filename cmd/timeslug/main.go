@@ -0,0 +1,380 @@
+// Command timeslug computes time-rotating slugs outside Terraform using the
+// same generation code as the provider.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
+)
+
+const usage = `Usage: timeslug <command> [flags]
+
+Commands:
+  generate  Derive the slug for an explicit period
+  current   Derive the slug for the period containing now (or -at)
+  window    Derive the slugs for a window of periods centered on -anchor
+  verify    Check whether a slug belongs to a window of periods
+
+The seed is read from -seed or the TIMESLUG_SEED environment variable.
+Run "timeslug <command> -h" for the flags of a command.
+`
+
+var (
+	// errNoMatch signals a failed verification, reported through the exit code.
+	errNoMatch = errors.New("slug does not match any period")
+	// errFlags signals a flag error the flag set has already reported.
+	errFlags = errors.New("invalid flags")
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	commands := map[string]func([]string, io.Writer, io.Writer) error{
+		"generate": generateCmd,
+		"current":  currentCmd,
+		"window":   windowCmd,
+		"verify":   verifyCmd,
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if args[0] != "-h" && args[0] != "-help" && args[0] != "help" {
+			fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		}
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	err := cmd(args[1:], stdout, stderr)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errNoMatch):
+		return 1
+	case errors.Is(err, errFlags):
+		return 2
+	case errors.As(err, new(usageError)):
+		fmt.Fprintf(stderr, "timeslug %s: %s\n", args[0], err)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "timeslug %s: %s\n", args[0], err)
+		return 1
+	}
+}
+
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// options holds the flags shared by all commands.
+type options struct {
+	seed      string
+	mode      string
+	length    int
+	version   int
+	language  string
+	wordlist  string
+	separator *string
+	wordCase  string
+	namespace string
+	output    string
+
+	// Set by commands that derive periods from a time.
+	interval string
+	isoWeek  bool
+	epoch    string
+	timezone string
+}
+
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	opts := &options{}
+	fs.StringVar(&opts.seed, "seed", os.Getenv("TIMESLUG_SEED"), "secret seed (default $TIMESLUG_SEED)")
+	fs.StringVar(&opts.mode, "mode", "bip39", "output mode: bip39 or obfuscated")
+	fs.IntVar(&opts.length, "length", 3, "words for bip39 (1-256, 1-24 with -algorithm-version 1), characters for obfuscated (1-1024)")
+	fs.IntVar(&opts.version, "algorithm-version", timeslug.DefaultVersion, "algorithm version: 1, 2 (obfuscated slugs of exactly -length characters) or 3 (2 derived with HKDF)")
+	fs.StringVar(&opts.language, "language", timeslug.DefaultLanguage, "BIP39 wordlist language: "+strings.Join(timeslug.Languages(), ", "))
+	fs.StringVar(&opts.wordlist, "wordlist", "", "custom wordlist file, one word per line, replacing the BIP39 wordlist")
+	fs.Func("separator", "string joining the words of bip39 slugs (default none, an ideographic space for japanese)", func(s string) error {
+		opts.separator = &s
+		return nil
+	})
+	fs.StringVar(&opts.wordCase, "case", "", "case of bip39 words: lower, title, camel or upper (default lower, or the words as given with -wordlist)")
+	fs.StringVar(&opts.namespace, "namespace", "", "namespace separating the slugs of services sharing a seed (requires -algorithm-version 3)")
+	fs.StringVar(&opts.output, "output", "plain", "output format: plain, json or table")
+	return fs, opts
+}
+
+// addIntervalFlags adds the flags of commands that derive periods from a time.
+func addIntervalFlags(fs *flag.FlagSet, opts *options) {
+	fs.StringVar(&opts.interval, "interval", "day", "rotation interval: second, minute, hour, day, week, month, quarter, year, or a multiple such as 15m, 6h or 2w")
+	fs.BoolVar(&opts.isoWeek, "iso-week", false, "use ISO 8601 week periods (2026-W06) for the week interval")
+	fs.StringVar(&opts.epoch, "epoch", "", "time multiples such as 15m are counted from (default 1970-01-01T00:00:00Z)")
+	fs.StringVar(&opts.timezone, "timezone", "UTC", "IANA time zone periods are computed in")
+}
+
+func (opts *options) generator() (*timeslug.Generator, error) {
+	loc, err := timeslug.LoadLocation(opts.timezone)
+	if err != nil {
+		return nil, err
+	}
+	var words []string
+	if opts.wordlist != "" {
+		if words, err = timeslug.LoadWordlist(opts.wordlist); err != nil {
+			return nil, err
+		}
+	}
+	epoch := time.Unix(0, 0)
+	if opts.epoch != "" {
+		if epoch, err = timeslug.ParseTimeIn(opts.epoch, loc); err != nil {
+			return nil, fmt.Errorf("epoch: %w", err)
+		}
+	}
+	options := []timeslug.Option{
+		timeslug.WithLength(opts.length),
+		timeslug.WithInterval(opts.interval),
+		timeslug.WithISOWeek(opts.isoWeek),
+		timeslug.WithEpoch(epoch),
+		timeslug.WithLocation(loc),
+		timeslug.WithMode(opts.mode),
+		timeslug.WithVersion(opts.version),
+		timeslug.WithLanguage(opts.language),
+		timeslug.WithWordlist(words),
+		timeslug.WithNamespace(opts.namespace),
+	}
+	if opts.wordCase != "" {
+		options = append(options, timeslug.WithCase(opts.wordCase))
+	}
+	if opts.separator != nil {
+		options = append(options, timeslug.WithSeparator(*opts.separator))
+	}
+	return timeslug.New(opts.seed, options...)
+}
+
+func parse(fs *flag.FlagSet, opts *options, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlags
+	}
+	if fs.NArg() > 0 {
+		return usageError(fmt.Sprintf("unexpected argument %q", fs.Arg(0)))
+	}
+	if opts.seed == "" {
+		return usageError("seed is required: set -seed or TIMESLUG_SEED")
+	}
+	switch opts.output {
+	case "plain", "json", "table":
+		return nil
+	}
+	return usageError(fmt.Sprintf("invalid output format: %s", opts.output))
+}
+
+func generateCmd(args []string, stdout, stderr io.Writer) error {
+	fs, opts := newFlagSet("generate", stderr)
+	period := fs.String("period", "", "period string (e.g., 2026-02-03)")
+	if err := parse(fs, opts, args); err != nil {
+		return err
+	}
+	if *period == "" {
+		return usageError("period is required")
+	}
+
+	g, err := opts.generator()
+	if err != nil {
+		return err
+	}
+	return writeSlug(stdout, opts.output, g.Derive(*period))
+}
+
+func currentCmd(args []string, stdout, stderr io.Writer) error {
+	fs, opts := newFlagSet("current", stderr)
+	addIntervalFlags(fs, opts)
+	at := fs.String("at", "", "point in time (default now)")
+	if err := parse(fs, opts, args); err != nil {
+		return err
+	}
+	g, err := opts.generator()
+	if err != nil {
+		return err
+	}
+	t, err := parseTime(g, *at)
+	if err != nil {
+		return err
+	}
+	return writeSlug(stdout, opts.output, g.At(t))
+}
+
+func windowCmd(args []string, stdout, stderr io.Writer) error {
+	fs, opts := newFlagSet("window", stderr)
+	addIntervalFlags(fs, opts)
+	anchor := fs.String("anchor", "", "center of the window (default now)")
+	window := fs.Int("window", 7, "number of periods in the window")
+	if err := parse(fs, opts, args); err != nil {
+		return err
+	}
+	if *window < 1 {
+		return usageError("window must be at least 1")
+	}
+	g, err := opts.generator()
+	if err != nil {
+		return err
+	}
+	t, err := parseTime(g, *anchor)
+	if err != nil {
+		return err
+	}
+	slugs, err := g.Window(t, *window)
+	if err != nil {
+		return err
+	}
+	return writeSlugs(stdout, opts.output, slugs)
+}
+
+func verifyCmd(args []string, stdout, stderr io.Writer) error {
+	fs, opts := newFlagSet("verify", stderr)
+	addIntervalFlags(fs, opts)
+	at := fs.String("at", "", "time to verify at (default now)")
+	tolerance := fs.Int("tolerance", 1, "number of periods before and after -at that are also accepted")
+	slug := fs.String("slug", "", "slug to verify")
+	if err := parse(fs, opts, args); err != nil {
+		return err
+	}
+	if *slug == "" {
+		return usageError("slug is required")
+	}
+	if *tolerance < 0 {
+		return usageError("tolerance must not be negative")
+	}
+	g, err := opts.generator()
+	if err != nil {
+		return err
+	}
+	t, err := parseTime(g, *at)
+	if err != nil {
+		return err
+	}
+	match, err := g.Verify(*slug, t, *tolerance)
+	if errors.Is(err, timeslug.ErrNoMatch) {
+		if err := writeNoMatch(stdout, opts.output); err != nil {
+			return err
+		}
+		return errNoMatch
+	}
+	if err != nil {
+		return err
+	}
+	return writeMatch(stdout, opts.output, match)
+}
+
+// parseTime parses a -at or -anchor flag in the time zone of g, defaulting
+// to now.
+func parseTime(g *timeslug.Generator, s string) (time.Time, error) {
+	if s == "" {
+		return time.Now(), nil
+	}
+	return g.ParseTime(s)
+}
+
+type jsonSlug struct {
+	Slug   string `json:"slug"`
+	Period string `json:"period"`
+	Hash   string `json:"hash"`
+}
+
+type jsonMatch struct {
+	Valid  bool   `json:"valid"`
+	Period string `json:"period,omitempty"`
+	Offset int    `json:"offset"`
+}
+
+func writeSlug(w io.Writer, output string, s timeslug.Slug) error {
+	switch output {
+	case "json":
+		return writeJSON(w, jsonSlug{Slug: s.Value, Period: s.Period, Hash: s.Hash})
+	case "table":
+		return writeTable(w, []timeslug.Slug{s})
+	}
+	_, err := fmt.Fprintln(w, s.Value)
+	return err
+}
+
+func writeSlugs(w io.Writer, output string, slugs []timeslug.Slug) error {
+	switch output {
+	case "json":
+		out := make([]jsonSlug, len(slugs))
+		for i, s := range slugs {
+			out[i] = jsonSlug{Slug: s.Value, Period: s.Period, Hash: s.Hash}
+		}
+		return writeJSON(w, out)
+	case "table":
+		return writeTable(w, slugs)
+	}
+	for _, s := range slugs {
+		if _, err := fmt.Fprintln(w, s.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMatch(w io.Writer, output string, m timeslug.Match) error {
+	switch output {
+	case "json":
+		return writeJSON(w, jsonMatch{Valid: true, Period: m.Period, Offset: m.Offset})
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "VALID\tPERIOD\tOFFSET")
+		fmt.Fprintf(tw, "true\t%s\t%d\n", m.Period, m.Offset)
+		return tw.Flush()
+	}
+	_, err := fmt.Fprintln(w, m.Period)
+	return err
+}
+
+func writeNoMatch(w io.Writer, output string) error {
+	switch output {
+	case "json":
+		return writeJSON(w, jsonMatch{Valid: false})
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "VALID\tPERIOD\tOFFSET")
+		fmt.Fprintln(tw, "false\t-\t-")
+		return tw.Flush()
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeTable(w io.Writer, slugs []timeslug.Slug) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PERIOD\tSLUG\tHASH")
+	for _, s := range slugs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Period, s.Value, s.Hash)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runCmd(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		args []string
+		code int
+		out  string
+	}{
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03"}, 0, "exoticangryanswer\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-mode", "obfuscated", "-length", "16"}, 0, "trybeambold8\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-mode", "obfuscated", "-length", "8", "-algorithm-version", "2"}, 0, "trybeamb\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-04", "-mode", "obfuscated", "-length", "12", "-algorithm-version", "3"}, 0, "techmomintva\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-namespace", "api", "-algorithm-version", "3"}, 0, "henlabeladmit\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-language", "english", "-separator", "-"}, 0, "exotic-angry-answer\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-separator", ".", "-case", "upper"}, 0, "EXOTIC.ANGRY.ANSWER\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-language", "spanish"}, 0, "espejoalfileralma\n"},
+		{[]string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-language", "japanese", "-separator", "-"}, 0, "こすう-いさん-いす\u3099れ\n"}, // NFKD normalized,
+		{[]string{"current", "-seed", "seedphrase", "-at", "2026-02-03T15:04:05"}, 0, "exoticangryanswer\n"},
+		{[]string{"current", "-seed", "seedphrase", "-at", "2026-02-03", "-interval", "quarter", "-mode", "obfuscated", "-length", "16"}, 0, "proboxfast101\n"},
+		{[]string{"current", "-seed", "seedphrase", "-at", "2026-02-03T12:20", "-interval", "15m"}, 0, "sustainfortunegarment\n"},
+		{[]string{"current", "-seed", "seedphrase", "-at", "2026-02-03T23:00", "-timezone", "America/New_York"}, 0, "exoticangryanswer\n"},
+		// Multiples count from the Unix epoch, not local midnight, like the
+		// provider (internal/provider TestGeneratorModel).
+		{[]string{"current", "-seed", "seedphrase", "-at", "2026-02-03T12:00:00Z", "-interval", "6h", "-timezone", "America/New_York"}, 0, "pentradeimpulse\n"},
+		{[]string{"window", "-seed", "seedphrase", "-anchor", "2026-02-03", "-window", "3", "-interval", "week", "-iso-week"}, 0, "ketchupvitalplay\ngarlicsolutiontackle\nswingdogquantum\n"},
+		{[]string{"window", "-seed", "seedphrase", "-anchor", "2026-02-04", "-window", "1", "-mode", "obfuscated", "-length", "16"}, 0, "brightbeamvivar\n"},
+		{[]string{"verify", "-seed", "seedphrase", "-at", "2026-02-04", "-slug", "exoticangryanswer"}, 0, "2026-02-03\n"},
+		{[]string{"verify", "-seed", "seedphrase", "-at", "2026-02-10", "-slug", "exoticangryanswer"}, 1, ""},
+	}
+	for _, tc := range cases {
+		code, out, stderr := runCmd(t, tc.args...)
+		if code != tc.code || out != tc.out {
+			t.Errorf("%v: got %d/%q (stderr %q), want %d/%q", tc.args, code, out, stderr, tc.code, tc.out)
+		}
+	}
+}
+
+func TestRunSeedFromEnv(t *testing.T) {
+	t.Setenv("TIMESLUG_SEED", "seedphrase")
+	if code, out, _ := runCmd(t, "generate", "-period", "2026-02-03"); code != 0 || out != "exoticangryanswer\n" {
+		t.Errorf("got %d/%q", code, out)
+	}
+}
+
+func TestRunWordlist(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(name, []byte("# colors\nred\ngreen\nblue\ngold\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	code, out, stderr := runCmd(t, "generate", "-seed", "seedphrase", "-period", "2026-02-03", "-length", "4", "-separator", "-", "-wordlist", name)
+	if code != 0 || out != "green-green-red-red\n" {
+		t.Errorf("got %d/%q (stderr %q)", code, out, stderr)
+	}
+	brands := filepath.Join(t.TempDir(), "brands.txt")
+	if err := os.WriteFile(brands, []byte("Acme\nNASA\nred\nBlue\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for c, want := range map[string]string{"": "NASA-NASA-Acme-Acme\n", "lower": "nasa-nasa-acme-acme\n", "camel": "nasa-Nasa-Acme-Acme\n"} {
+		args := []string{"generate", "-seed", "seedphrase", "-period", "2026-02-03", "-length", "4", "-separator", "-", "-wordlist", brands}
+		if c != "" {
+			args = append(args, "-case", c)
+		}
+		if code, out, stderr := runCmd(t, args...); code != 0 || out != want {
+			t.Errorf("case %q: got %d/%q (stderr %q)", c, code, out, stderr)
+		}
+	}
+	if code, _, stderr := runCmd(t, "generate", "-seed", "s", "-period", "2026", "-wordlist", name+".missing"); code != 1 || !strings.Contains(stderr, "invalid wordlist") {
+		t.Errorf("got %d/%q", code, stderr)
+	}
+}
+
+func TestRunOutputs(t *testing.T) {
+	_, out, _ := runCmd(t, "window", "-seed", "seedphrase", "-anchor", "2026-02-04", "-window", "3", "-mode", "obfuscated", "-length", "16", "-output", "json")
+	var slugs []jsonSlug
+	if err := json.Unmarshal([]byte(out), &slugs); err != nil {
+		t.Fatal(err)
+	}
+	if len(slugs) != 3 || slugs[0].Slug != "trybeambold8" || slugs[0].Hash != "5d3bf0d55db67ea2" {
+		t.Errorf("got %+v", slugs)
+	}
+
+	_, out, _ = runCmd(t, "verify", "-seed", "seedphrase", "-at", "2026-02-04", "-slug", "exoticangryanswer", "-output", "json")
+	var match jsonMatch
+	if err := json.Unmarshal([]byte(out), &match); err != nil {
+		t.Fatal(err)
+	}
+	if !match.Valid || match.Period != "2026-02-03" || match.Offset != -1 {
+		t.Errorf("got %+v", match)
+	}
+
+	_, out, _ = runCmd(t, "generate", "-seed", "seedphrase", "-period", "2026-02-03", "-output", "table")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "PERIOD") || !strings.Contains(lines[1], "exoticangryanswer") {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Setenv("TIMESLUG_SEED", "")
+	cases := [][]string{
+		{},
+		{"unknown"},
+		{"generate", "-period", "2026-02-03"},
+		{"generate", "-seed", "s"},
+		{"generate", "-seed", "s", "-period", "2026-02-03", "-output", "xml"},
+		{"generate", "-seed", "s", "-bogus"},
+		{"window", "-seed", "s", "-window", "0"},
+		{"verify", "-seed", "s", "-slug", "x", "-tolerance", "-1"},
+		{"verify", "-seed", "s"},
+	}
+	for _, args := range cases {
+		if code, _, _ := runCmd(t, args...); code != 2 {
+			t.Errorf("%v: got exit %d, want 2", args, code)
+		}
+	}
+	if code, _, stderr := runCmd(t, "current", "-seed", "s", "-at", "invalid"); code != 1 || !strings.Contains(stderr, "invalid time") {
+		t.Errorf("got %d/%q", code, stderr)
+	}
+	if code, _, stderr := runCmd(t, "current", "-seed", "s", "-timezone", "Nowhere/Special"); code != 1 || !strings.Contains(stderr, "invalid timezone") {
+		t.Errorf("got %d/%q", code, stderr)
+	}
+	if code, _, stderr := runCmd(t, "generate", "-seed", "s", "-period", "2026", "-language", "klingon"); code != 1 || !strings.Contains(stderr, "invalid language") {
+		t.Errorf("got %d/%q", code, stderr)
+	}
+	if code, _, stderr := runCmd(t, "generate", "-seed", "s", "-period", "2026", "-mode", "words"); code != 1 || !strings.Contains(stderr, "invalid mode") {
+		t.Errorf("got %d/%q", code, stderr)
+	}
+}
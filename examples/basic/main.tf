@@ -46,11 +46,11 @@ output "obfuscated_slugs" {
 }
 
 output "current_bip39" {
-  description = "Current BIP39 slug (center of window)"
-  value       = data.timeslug_slugs.bip39_daily.slugs[3].slug
+  description = "Current BIP39 slug"
+  value       = data.timeslug_slugs.bip39_daily.current.slug
 }
 
 output "current_obfuscated" {
-  description = "Current obfuscated slug (center of window)"
-  value       = data.timeslug_slugs.obfuscated_daily.slugs[3].slug
+  description = "Current obfuscated slug"
+  value       = data.timeslug_slugs.obfuscated_daily.current.slug
 }
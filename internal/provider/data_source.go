@@ -4,29 +4,38 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
 )
 
 var (
-	_ datasource.DataSource              = &slugsDataSource{}
-	_ datasource.DataSourceWithConfigure = &slugsDataSource{}
+	_ datasource.DataSource                   = &slugsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &slugsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &slugsDataSource{}
 )
 
 type slugsDataSource struct {
-	seed string
+	providerData
 }
 
 type slugsModel struct {
-	Anchor   types.String `tfsdk:"anchor"`
-	Length   types.Int64  `tfsdk:"length"`
-	Window   types.Int64  `tfsdk:"window"`
-	Interval types.String `tfsdk:"interval"`
-	Mode     types.String `tfsdk:"mode"`
-	ID       types.String `tfsdk:"id"`
-	Slugs    types.List   `tfsdk:"slugs"`
+	generatorModel
+	Anchor types.String `tfsdk:"anchor"`
+	Window types.Int64  `tfsdk:"window"`
+	ID     types.String `tfsdk:"id"`
+	Slugs  types.List   `tfsdk:"slugs"`
+
+	Current       types.Object `tfsdk:"current"`
+	Previous      types.Object `tfsdk:"previous"`
+	Next          types.Object `tfsdk:"next"`
+	SlugsByPeriod types.Map    `tfsdk:"slugs_by_period"`
 }
 
 func NewSlugsDataSource() datasource.DataSource {
@@ -44,36 +53,115 @@ func (d *slugsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			"anchor": schema.StringAttribute{
 				Description: "Center point for the time window (e.g., 2006-01-02 or 2006-01-02T15:04:05).",
 				Required:    true,
+				Validators:  timeValidators,
 			},
 			"length": schema.Int64Attribute{
-				Description: "Slug length: words (1-24) for bip39, characters for obfuscated. Default: 3",
+				Description: lengthDescription,
 				Optional:    true,
+				Validators:  lengthValidators,
 			},
 			"window": schema.Int64Attribute{
-				Description: "Number of periods in the window. Default: 7",
+				Description: "Number of periods in the window. Default: the provider's window, or 7",
 				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
 			},
 			"interval": schema.StringAttribute{
-				Description: "Rotation interval: second, minute, hour, day, week. Default: day",
+				Description: intervalDescription,
+				Optional:    true,
+				Validators:  intervalValidators,
+			},
+			"epoch": schema.StringAttribute{
+				Description: epochDescription,
+				Optional:    true,
+				Validators:  timeValidators,
+			},
+			"iso_week": schema.BoolAttribute{
+				Description: isoWeekDescription,
 				Optional:    true,
 			},
+			"timezone": schema.StringAttribute{
+				Description: timezoneDescription,
+				Optional:    true,
+				Validators:  timezoneValidators,
+			},
 			"mode": schema.StringAttribute{
-				Description: "Output mode: bip39 (words) or obfuscated (alphanumeric). Default: bip39",
+				Description: modeDescription,
+				Optional:    true,
+				Validators:  modeValidators,
+			},
+			"language": schema.StringAttribute{
+				Description: languageDescription,
+				Optional:    true,
+				Validators:  languageValidators,
+			},
+			"separator": schema.StringAttribute{
+				Description: separatorDescription,
+				Optional:    true,
+			},
+			"case": schema.StringAttribute{
+				Description: caseDescription,
+				Optional:    true,
+				Validators:  caseValidators,
+			},
+			"wordlist": schema.ListAttribute{
+				Description: wordlistDescription,
+				ElementType: types.StringType,
 				Optional:    true,
 			},
+			"wordlist_file": schema.StringAttribute{
+				Description: wordlistFileDescription,
+				Optional:    true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: namespaceDescription,
+				Optional:    true,
+			},
+			"algorithm_version": schema.Int64Attribute{
+				Description: versionDescription,
+				Optional:    true,
+				Validators:  versionValidators,
+			},
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
 			"slugs": schema.ListNestedAttribute{
-				Computed: true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"slug":   schema.StringAttribute{Computed: true},
-						"period": schema.StringAttribute{Computed: true},
-						"hash":   schema.StringAttribute{Computed: true},
-					},
-				},
+				Computed:     true,
+				NestedObject: schema.NestedAttributeObject{Attributes: seededSlugAttributes()},
+			},
+			"current": schema.SingleNestedAttribute{
+				Description: "Slug of the period containing anchor, whatever the window.",
+				Computed:    true,
+				Attributes:  seededSlugAttributes(),
+			},
+			"previous": schema.SingleNestedAttribute{
+				Description: "Slug of the period before current.",
+				Computed:    true,
+				Attributes:  seededSlugAttributes(),
+			},
+			"next": schema.SingleNestedAttribute{
+				Description: "Slug of the period after current.",
+				Computed:    true,
+				Attributes:  seededSlugAttributes(),
 			},
+			"slugs_by_period": schema.MapAttribute{
+				Description: "Slugs of the window keyed by period (e.g., 2026-02-03).",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// seededSlugAttributes returns the schema of a slug object of
+// seededSlugAttrTypes.
+func seededSlugAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"slug":   schema.StringAttribute{Computed: true},
+		"period": schema.StringAttribute{Computed: true},
+		"hash":   schema.StringAttribute{Computed: true},
+		"seed_id": schema.StringAttribute{
+			Description: "id of the provider seeds block the slug was derived with, null without seeds blocks.",
+			Computed:    true,
 		},
 	}
 }
@@ -82,67 +170,166 @@ func (d *slugsDataSource) Configure(_ context.Context, req datasource.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	seed, ok := req.ProviderData.(string)
+	data, ok := req.ProviderData.(providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Config Error", fmt.Sprintf("expected string, got %T", req.ProviderData))
+		resp.Diagnostics.AddError("Config Error", fmt.Sprintf("expected providerData, got %T", req.ProviderData))
 		return
 	}
-	d.seed = seed
+	d.providerData = data
 }
 
-func (d *slugsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+func (d *slugsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
 	var data slugsModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.validate(&resp.Diagnostics)
+}
 
-	// Defaults
-	length := int64(3)
-	window := int64(7)
-	interval := "day"
-	mode := "bip39"
+func (d *slugsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data slugsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if !data.Length.IsNull() {
-		length = data.Length.ValueInt64()
+	if unknownRead(d.providerData, data.unknownAttribute(map[string]attr.Value{"anchor": data.Anchor, "window": data.Window}), req, resp) {
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ID = types.StringUnknown()
+		data.Slugs = types.ListUnknown(types.ObjectType{AttrTypes: seededSlugAttrTypes})
+		data.Current = types.ObjectUnknown(seededSlugAttrTypes)
+		data.Previous = types.ObjectUnknown(seededSlugAttrTypes)
+		data.Next = types.ObjectUnknown(seededSlugAttrTypes)
+		data.SlugsByPeriod = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
+
+	window := int64(d.defaultWindow())
 	if !data.Window.IsNull() {
 		window = data.Window.ValueInt64()
 	}
-	if !data.Interval.IsNull() {
-		interval = data.Interval.ValueString()
+
+	g, err := data.generator(d.providerData)
+	if err != nil {
+		data.addError(&resp.Diagnostics, "Generation Failed", err)
+		return
 	}
-	if !data.Mode.IsNull() {
-		mode = data.Mode.ValueString()
+	anchor, err := g.ParseTime(data.Anchor.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("anchor"), "Generation Failed", err.Error())
+		return
 	}
-
-	slugs, err := Generate(d.seed, data.Anchor.ValueString(), int(length), int(window), interval, mode)
+	slugs, err := g.Window(anchor, int(window))
 	if err != nil {
-		resp.Diagnostics.AddError("Generation Failed", err.Error())
+		data.addError(&resp.Diagnostics, "Generation Failed", err)
 		return
 	}
 
-	// Build list
-	attrTypes := map[string]attr.Type{
-		"slug":   types.StringType,
-		"period": types.StringType,
-		"hash":   types.StringType,
+	// The periods around the anchor, independent of the window's size.
+	around, err := g.Window(anchor, 3)
+	if err != nil {
+		data.addError(&resp.Diagnostics, "Generation Failed", err)
+		return
 	}
 
-	values := make([]attr.Value, len(slugs))
-	for i, s := range slugs {
-		values[i], _ = types.ObjectValue(attrTypes, map[string]attr.Value{
-			"slug":   types.StringValue(s.Value),
-			"period": types.StringValue(s.Period),
-			"hash":   types.StringValue(s.Hash),
-		})
+	list, diags := slugsValue(slugs, true)
+	resp.Diagnostics.Append(diags...)
+	data.Previous, diags = slugValue(around[0], true)
+	resp.Diagnostics.Append(diags...)
+	data.Current, diags = slugValue(around[1], true)
+	resp.Diagnostics.Append(diags...)
+	data.Next, diags = slugValue(around[2], true)
+	resp.Diagnostics.Append(diags...)
+	byPeriod := make(map[string]attr.Value, len(slugs))
+	for _, s := range slugs {
+		byPeriod[s.Period] = types.StringValue(s.Value)
 	}
-
-	list, diags := types.ListValue(types.ObjectType{AttrTypes: attrTypes}, values)
+	data.SlugsByPeriod, diags = types.MapValue(types.StringType, byPeriod)
 	resp.Diagnostics.Append(diags...)
 
-	data.ID = types.StringValue(fmt.Sprintf("%s-%s-%s-%d-%d", data.Anchor.ValueString(), mode, interval, length, window))
+	data.ID = types.StringValue(data.id(d.providerData, g, data.Anchor.ValueString(), window))
 	data.Slugs = list
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// unknownRead reports whether a data source read has inputs unknown at plan
+// time, from the provider or the data source attribute. Reads are deferred
+// when the client allows it, and the caller leaves its outputs unknown;
+// otherwise they fail with an error naming the unknown input.
+func unknownRead(p providerData, attribute string, req datasource.ReadRequest, resp *datasource.ReadResponse) bool {
+	if !p.unknown && attribute == "" {
+		return false
+	}
+	if !req.ClientCapabilities.DeferralAllowed {
+		addUnknownError(&resp.Diagnostics, p.unknown, attribute)
+		return true
+	}
+	reason := datasource.DeferredReasonDataSourceConfigUnknown
+	if p.unknown {
+		reason = datasource.DeferredReasonProviderConfigUnknown
+	}
+	resp.Deferred = &datasource.Deferred{Reason: reason}
+	return true
+}
+
+// slugAttrTypes is the object shape of function results.
+var slugAttrTypes = map[string]attr.Type{
+	"slug":   types.StringType,
+	"period": types.StringType,
+	"hash":   types.StringType,
+}
+
+// seededSlugAttrTypes is the object shape of timeslug_slugs results, which
+// also name the provider seed each slug was derived with.
+var seededSlugAttrTypes = map[string]attr.Type{
+	"slug":    types.StringType,
+	"period":  types.StringType,
+	"hash":    types.StringType,
+	"seed_id": types.StringType,
+}
+
+// slugValue converts a slug to an object of slugAttrTypes, or of
+// seededSlugAttrTypes if seeded is set.
+func slugValue(s timeslug.Slug, seeded bool) (types.Object, diag.Diagnostics) {
+	attrs := map[string]attr.Value{
+		"slug":   types.StringValue(s.Value),
+		"period": types.StringValue(s.Period),
+		"hash":   types.StringValue(s.Hash),
+	}
+	if !seeded {
+		return types.ObjectValue(slugAttrTypes, attrs)
+	}
+	attrs["seed_id"] = seedIDValue(s.SeedID)
+	return types.ObjectValue(seededSlugAttrTypes, attrs)
+}
+
+func slugsValue(slugs []timeslug.Slug, seeded bool) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := make([]attr.Value, len(slugs))
+	for i, s := range slugs {
+		var d diag.Diagnostics
+		values[i], d = slugValue(s, seeded)
+		diags.Append(d...)
+	}
+	attrTypes := slugAttrTypes
+	if seeded {
+		attrTypes = seededSlugAttrTypes
+	}
+	list, d := types.ListValue(types.ObjectType{AttrTypes: attrTypes}, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// seedIDValue returns the seed_id of a slug, null without the provider's
+// seeds blocks.
+func seedIDValue(id string) types.String {
+	if id == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(id)
+}
@@ -2,11 +2,19 @@ package provider
 
 import (
 	"context"
+	"regexp"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestSlugsDataSource(t *testing.T) {
@@ -27,8 +35,8 @@ func TestSlugsDataSource(t *testing.T) {
 		t.Fatal(schemaResp.Diagnostics)
 	}
 	required := []string{"anchor"}
-	optional := []string{"length", "window", "interval", "mode"}
-	computed := []string{"id", "slugs"}
+	optional := []string{"length", "window", "interval", "iso_week", "epoch", "timezone", "mode", "language", "separator", "case", "wordlist", "wordlist_file", "namespace", "algorithm_version"}
+	computed := []string{"id", "slugs", "current", "previous", "next", "slugs_by_period"}
 	for _, attr := range slices.Concat(required, optional, computed) {
 		if _, ok := schemaResp.Schema.Attributes[attr]; !ok {
 			t.Errorf("missing attribute %q", attr)
@@ -38,7 +46,7 @@ func TestSlugsDataSource(t *testing.T) {
 	// Configure
 	concrete := ds.(*slugsDataSource)
 	configResp := &datasource.ConfigureResponse{}
-	concrete.Configure(ctx, datasource.ConfigureRequest{ProviderData: "test-seed"}, configResp)
+	concrete.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData{seed: "test-seed"}}, configResp)
 	if configResp.Diagnostics.HasError() {
 		t.Fatal(configResp.Diagnostics)
 	}
@@ -48,6 +56,113 @@ func TestSlugsDataSource(t *testing.T) {
 	}
 }
 
+func TestSlugsDataSourceUnknown(t *testing.T) {
+	ctx := context.Background()
+	ds := NewSlugsDataSource().(*slugsDataSource)
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	// anchor comes from a resource attribute that is unknown at plan time.
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	values["anchor"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, values)}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}
+
+	cases := []struct {
+		name     string
+		data     providerData
+		deferral bool
+		reason   datasource.DeferredReason
+		err      path.Path
+	}{
+		{"config deferred", providerData{seed: "seedphrase"}, true, datasource.DeferredReasonDataSourceConfigUnknown, path.Empty()},
+		{"provider deferred", providerData{unknown: true}, true, datasource.DeferredReasonProviderConfigUnknown, path.Empty()},
+		{"config unknown", providerData{seed: "seedphrase"}, false, 0, path.Root("anchor")},
+		{"provider unknown", providerData{unknown: true}, false, 0, path.Empty()},
+	}
+	for _, tc := range cases {
+		ds.providerData = tc.data
+		resp := &datasource.ReadResponse{State: state}
+		ds.Read(ctx, datasource.ReadRequest{Config: config, ClientCapabilities: datasource.ReadClientCapabilities{DeferralAllowed: tc.deferral}}, resp)
+		if !tc.deferral {
+			// Without deferral, reads fail with an error on the unknown input.
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || resp.Deferred != nil || !strings.Contains(errs[0].Detail(), "unknown at plan time") {
+				t.Errorf("%s: got deferred=%v %v", tc.name, resp.Deferred, resp.Diagnostics)
+				continue
+			}
+			got := path.Empty()
+			if d, ok := errs[0].(diag.DiagnosticWithPath); ok {
+				got = d.Path()
+			}
+			if !got.Equal(tc.err) {
+				t.Errorf("%s: got path %s, want %s", tc.name, got, tc.err)
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", tc.name, resp.Diagnostics)
+		}
+		if resp.Deferred == nil || resp.Deferred.Reason != tc.reason {
+			t.Errorf("%s: got deferred=%v", tc.name, resp.Deferred)
+		}
+	}
+}
+
+func TestSlugsDataSourceCurrent(t *testing.T) {
+	ctx := context.Background()
+	ds := NewSlugsDataSource().(*slugsDataSource)
+	ds.providerData = providerData{seed: "seedphrase"}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	// An even window has no center index, but current is still the anchor's.
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	values["anchor"] = tftypes.NewValue(tftypes.String, "2026-02-03")
+	values["window"] = tftypes.NewValue(tftypes.Number, 2)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, values)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}}
+	ds.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var data slugsModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	for name, tc := range map[string]struct {
+		obj          types.Object
+		slug, period string
+	}{
+		"previous": {data.Previous, "", "2026-02-02"},
+		"current":  {data.Current, "exoticangryanswer", "2026-02-03"},
+		"next":     {data.Next, "", "2026-02-04"},
+	} {
+		attrs := tc.obj.Attributes()
+		if attrs["period"].(types.String).ValueString() != tc.period {
+			t.Errorf("%s: got %v, want period %s", name, tc.obj, tc.period)
+		}
+		if tc.slug != "" && attrs["slug"].(types.String).ValueString() != tc.slug {
+			t.Errorf("%s: got %v, want slug %s", name, tc.obj, tc.slug)
+		}
+	}
+	byPeriod := map[string]string{}
+	resp.Diagnostics.Append(data.SlugsByPeriod.ElementsAs(ctx, &byPeriod, false)...)
+	if len(byPeriod) != 2 || byPeriod["2026-02-03"] != "exoticangryanswer" || byPeriod["2026-02-02"] == "" {
+		t.Errorf("got slugs_by_period %v", byPeriod)
+	}
+}
+
 // Acceptance tests
 func TestAccSlugsDataSource_bip39(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -65,6 +180,10 @@ data "timeslug_slugs" "test" {
 				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.#", "3"),
 				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.slug", "exoticangryanswer"),
 				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.hash", "50011c26d0"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "current.slug", "exoticangryanswer"),
+				resource.TestCheckResourceAttrPair("data.timeslug_slugs.test", "previous.slug", "data.timeslug_slugs.test", "slugs.0.slug"),
+				resource.TestCheckResourceAttrPair("data.timeslug_slugs.test", "next.slug", "data.timeslug_slugs.test", "slugs.2.slug"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs_by_period.2026-02-03", "exoticangryanswer"),
 			),
 		}},
 	})
@@ -102,3 +221,342 @@ data "timeslug_slugs" "test" { anchor = "2026-01-15" }`,
 		}},
 	})
 }
+
+func TestAccSlugsDataSource_calendar(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor   = "2026-02-03"
+  window   = 3
+  interval = "month"
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.period", "2026-01"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.period", "2026-02"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.slug", "glasspinkcrowd"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.2.period", "2026-03"),
+			),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_isoWeek(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor   = "2026-02-03"
+  window   = 3
+  interval = "week"
+  iso_week = true
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.period", "2026-W05"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.period", "2026-W06"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.slug", "garlicsolutiontackle"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.2.period", "2026-W07"),
+			),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_multiple(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor   = "2026-02-03T12:20"
+  window   = 3
+  interval = "6h"
+  epoch    = "2026-01-01T00:30"
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.period", "2026-02-03T00:30/6h"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.period", "2026-02-03T06:30/6h"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.2.period", "2026-02-03T12:30/6h"),
+			),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_timezone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor   = "2026-11-01T05:30:00Z"
+  window   = 3
+  interval = "hour"
+  timezone = "America/New_York"
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.period", "2026-11-01T00-04:00"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.period", "2026-11-01T01-04:00"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.2.period", "2026-11-01T01-05:00"),
+			),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_version2(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor            = "2026-02-03"
+  length            = 32
+  window            = 1
+  mode              = "obfuscated"
+  algorithm_version = 2
+}`,
+			Check: resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "trybeambold8nodecopadrozoomlagri"),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_namespace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "api" {
+  anchor            = "2026-02-03"
+  window            = 1
+  namespace         = "api"
+  algorithm_version = 3
+}
+data "timeslug_slugs" "docs" {
+  anchor            = "2026-02-03"
+  window            = 1
+  namespace         = "docs"
+  algorithm_version = 3
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.api", "slugs.0.slug", "henlabeladmit"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.api", "id", "2026-02-03-bip39-day-3-1-api"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.docs", "slugs.0.slug", "globefocustiger"),
+			),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_providerVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" {
+  seed              = "seedphrase"
+  algorithm_version = 2
+}
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+  length = 8
+  window = 1
+  mode   = "obfuscated"
+}
+data "timeslug_slugs" "pinned" {
+  anchor            = "2026-02-03"
+  length            = 8
+  window            = 1
+  mode              = "obfuscated"
+  algorithm_version = 1
+}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "trybeamb"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.pinned", "slugs.0.slug", "trybeambold8"),
+			),
+		}, {
+			Config: `
+provider "timeslug" {
+  seed              = "seedphrase"
+  algorithm_version = 99
+}
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+}`,
+			ExpectError: regexp.MustCompile("invalid algorithm version"),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_language(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor    = "2026-02-03"
+  window    = 1
+  language  = "english"
+  separator = "-"
+}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "exotic-angry-answer"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.hash", "50011c26d0"),
+			),
+		}, {
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor   = "2026-02-03"
+  window   = 1
+  language = "italian"
+}`,
+			Check: resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "feltroalunnoambito"),
+		}, {
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor   = "2026-02-03"
+  language = "klingon"
+}`,
+			ExpectError: regexp.MustCompile(`value must be one of: \["chinese_simplified" .*"english"`),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_wordlist(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor    = "2026-02-03"
+  length    = 4
+  window    = 1
+  separator = "-"
+  wordlist  = ["red", "green", "blue", "gold"]
+}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "green-green-red-red"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "id", "2026-02-03-bip39-day-4-1-480591bfe8fa04e5"),
+			),
+		}, {
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor   = "2026-02-03"
+  wordlist = ["red", "green", "red"]
+}`,
+			ExpectError: regexp.MustCompile(`duplicate word "red"`),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_case(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+  window = 1
+  case   = "camel"
+}`,
+			Check: resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "exoticAngryAnswer"),
+		}, {
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+  case   = "snake"
+}`,
+			ExpectError: regexp.MustCompile(`value must be one of: \["lower" "title" "camel" "upper"\]`),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_longBIP39(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor            = "2026-02-03"
+  length            = 26
+  window            = 1
+  separator         = "-"
+  algorithm_version = 2
+}`,
+			Check: resource.TestMatchResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", regexp.MustCompile(`^exotic-angry-answer(-[a-z]+){22}-assume-protect$`)),
+		}, {
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+  length = 25
+}`,
+			ExpectError: regexp.MustCompile("derives at most 24"),
+		}},
+	})
+}
+
+func TestAccSlugsDataSource_validation(t *testing.T) {
+	var steps []resource.TestStep
+	for _, tc := range []struct{ attrs, err string }{
+		{`window = -1`, "value must be at least 1"},
+		{`length = 0`, "value must be between 1 and 1024"},
+		{`length = 1025`, "value must be between 1 and 1024"},
+		{"length = 257\n  mode   = \"bip39\"", "bip39 slugs are at most 256 words"},
+		{`mode = "words"`, `value must be one of: \["bip39" "obfuscated"\]`},
+		{`interval = "fortnight"`, "invalid interval: fortnight"},
+		{"length = 25\n  algorithm_version = 1", "derives at most 24 bip39 words"},
+		{"wordlist = [\"red\", \"green\"]\n  wordlist_file = \"words.txt\"", "wordlist_file conflicts with wordlist"},
+		{"namespace = \"api\"\n  algorithm_version = 2", "namespace requires algorithm_version = 3"},
+	} {
+		steps = append(steps, resource.TestStep{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+  ` + tc.attrs + `
+}`,
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(tc.err),
+		})
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+}
+
+func TestAccSlugsDataSource_unknownAnchor(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			// The period of a new rotating slug is only known after apply.
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+resource "timeslug_rotating_slug" "test" {}
+data "timeslug_slugs" "test" {
+  anchor = timeslug_rotating_slug.test.period
+  window = 1
+}
+output "slug" {
+  value = data.timeslug_slugs.test.slugs[0].slug
+}`,
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{plancheck.ExpectUnknownOutputValue("slug")},
+			},
+			Check: resource.TestCheckResourceAttrPair("data.timeslug_slugs.test", "slugs.0.slug", "timeslug_rotating_slug.test", "slug"),
+		}},
+	})
+}
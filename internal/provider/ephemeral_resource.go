@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &slugEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &slugEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &slugEphemeralResource{}
+)
+
+type slugEphemeralResource struct {
+	providerData
+}
+
+type slugEphemeralModel struct {
+	generatorModel
+	Anchor types.String `tfsdk:"anchor"`
+	Slug   types.String `tfsdk:"slug"`
+	Period types.String `tfsdk:"period"`
+	Hash   types.String `tfsdk:"hash"`
+}
+
+func NewSlugEphemeralResource() ephemeral.EphemeralResource {
+	return &slugEphemeralResource{}
+}
+
+func (e *slugEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_slug"
+}
+
+func (e *slugEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates the slug for the period containing anchor without persisting it to state.",
+		Attributes: map[string]schema.Attribute{
+			"anchor": schema.StringAttribute{
+				Description: "Point in time (e.g., 2006-01-02 or 2006-01-02T15:04:05).",
+				Required:    true,
+				Validators:  timeValidators,
+			},
+			"length": schema.Int64Attribute{
+				Description: lengthDescription,
+				Optional:    true,
+				Validators:  lengthValidators,
+			},
+			"interval": schema.StringAttribute{
+				Description: intervalDescription,
+				Optional:    true,
+				Validators:  intervalValidators,
+			},
+			"epoch": schema.StringAttribute{
+				Description: epochDescription,
+				Optional:    true,
+				Validators:  timeValidators,
+			},
+			"iso_week": schema.BoolAttribute{
+				Description: isoWeekDescription,
+				Optional:    true,
+			},
+			"timezone": schema.StringAttribute{
+				Description: timezoneDescription,
+				Optional:    true,
+				Validators:  timezoneValidators,
+			},
+			"mode": schema.StringAttribute{
+				Description: modeDescription,
+				Optional:    true,
+				Validators:  modeValidators,
+			},
+			"language": schema.StringAttribute{
+				Description: languageDescription,
+				Optional:    true,
+				Validators:  languageValidators,
+			},
+			"separator": schema.StringAttribute{
+				Description: separatorDescription,
+				Optional:    true,
+			},
+			"case": schema.StringAttribute{
+				Description: caseDescription,
+				Optional:    true,
+				Validators:  caseValidators,
+			},
+			"wordlist": schema.ListAttribute{
+				Description: wordlistDescription,
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"wordlist_file": schema.StringAttribute{
+				Description: wordlistFileDescription,
+				Optional:    true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: namespaceDescription,
+				Optional:    true,
+			},
+			"algorithm_version": schema.Int64Attribute{
+				Description: versionDescription,
+				Optional:    true,
+				Validators:  versionValidators,
+			},
+			"slug": schema.StringAttribute{
+				Description: "The generated slug value.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"period": schema.StringAttribute{
+				Description: "The time period this slug is valid for.",
+				Computed:    true,
+			},
+			"hash": schema.StringAttribute{
+				Description: "Verification hash for this slug.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (e *slugEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Config Error", fmt.Sprintf("expected providerData, got %T", req.ProviderData))
+		return
+	}
+	e.providerData = data
+}
+
+func (e *slugEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data slugEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.validate(&resp.Diagnostics)
+}
+
+func (e *slugEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data slugEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if attribute := data.unknownAttribute(map[string]attr.Value{"anchor": data.Anchor}); e.unknown || attribute != "" {
+		if !req.ClientCapabilities.DeferralAllowed {
+			addUnknownError(&resp.Diagnostics, e.unknown, attribute)
+			return
+		}
+		reason := ephemeral.DeferredReasonEphemeralResourceConfigUnknown
+		if e.unknown {
+			reason = ephemeral.DeferredReasonProviderConfigUnknown
+		}
+		resp.Deferred = &ephemeral.Deferred{Reason: reason}
+		return
+	}
+
+	g, err := data.generator(e.providerData)
+	if err != nil {
+		data.addError(&resp.Diagnostics, "Generation Failed", err)
+		return
+	}
+	anchor, err := g.ParseTime(data.Anchor.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("anchor"), "Generation Failed", err.Error())
+		return
+	}
+	slug := g.At(anchor)
+
+	data.Slug = types.StringValue(slug.Value)
+	data.Period = types.StringValue(slug.Period)
+	data.Hash = types.StringValue(slug.Hash)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
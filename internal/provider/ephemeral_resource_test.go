@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestSlugEphemeralResource(t *testing.T) {
+	ctx := context.Background()
+	e := NewSlugEphemeralResource()
+
+	// Metadata
+	metaResp := &ephemeral.MetadataResponse{}
+	e.Metadata(ctx, ephemeral.MetadataRequest{ProviderTypeName: "timeslug"}, metaResp)
+	if metaResp.TypeName != "timeslug_slug" {
+		t.Errorf("got type=%q", metaResp.TypeName)
+	}
+
+	// Schema
+	schemaResp := &ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatal(schemaResp.Diagnostics)
+	}
+	required := []string{"anchor"}
+	optional := []string{"length", "interval", "iso_week", "epoch", "timezone", "mode", "language", "separator", "case", "wordlist", "wordlist_file", "algorithm_version"}
+	computed := []string{"slug", "period", "hash"}
+	for _, attr := range slices.Concat(required, optional, computed) {
+		if _, ok := schemaResp.Schema.Attributes[attr]; !ok {
+			t.Errorf("missing attribute %q", attr)
+		}
+	}
+
+	// Configure
+	concrete := e.(*slugEphemeralResource)
+	configResp := &ephemeral.ConfigureResponse{}
+	concrete.Configure(ctx, ephemeral.ConfigureRequest{ProviderData: 123}, configResp)
+	if !configResp.Diagnostics.HasError() {
+		t.Error("expected error for wrong type")
+	}
+	configResp = &ephemeral.ConfigureResponse{}
+	concrete.Configure(ctx, ephemeral.ConfigureRequest{ProviderData: providerData{seed: "seedphrase"}}, configResp)
+	if configResp.Diagnostics.HasError() {
+		t.Fatal(configResp.Diagnostics)
+	}
+
+	// Open
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	values["anchor"] = tftypes.NewValue(tftypes.String, "2026-02-03")
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objType, values),
+	}
+	openResp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: config.Raw}}
+	e.Open(ctx, ephemeral.OpenRequest{Config: config}, openResp)
+	if openResp.Diagnostics.HasError() {
+		t.Fatal(openResp.Diagnostics)
+	}
+	var result slugEphemeralModel
+	openResp.Result.Get(ctx, &result)
+	if result.Slug.ValueString() != "exoticangryanswer" || result.Hash.ValueString() != "50011c26d0" {
+		t.Errorf("got %q/%q", result.Slug.ValueString(), result.Hash.ValueString())
+	}
+
+	// Open with an unknown length: deferred, or an error on length.
+	values["length"] = tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)
+	config.Raw = tftypes.NewValue(objType, values)
+	openResp = &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: config.Raw}}
+	e.Open(ctx, ephemeral.OpenRequest{Config: config, ClientCapabilities: ephemeral.OpenClientCapabilities{DeferralAllowed: true}}, openResp)
+	if openResp.Diagnostics.HasError() || openResp.Deferred == nil || openResp.Deferred.Reason != ephemeral.DeferredReasonEphemeralResourceConfigUnknown {
+		t.Errorf("got deferred=%v %v", openResp.Deferred, openResp.Diagnostics)
+	}
+	openResp = &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: config.Raw}}
+	e.Open(ctx, ephemeral.OpenRequest{Config: config}, openResp)
+	if errs := openResp.Diagnostics.Errors(); len(errs) != 1 || openResp.Deferred != nil || !strings.HasPrefix(errs[0].Detail(), "length is unknown") {
+		t.Errorf("got deferred=%v %v", openResp.Deferred, openResp.Diagnostics)
+	}
+}
+
+// Acceptance tests
+func TestAccSlugEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"timeslug": providerserver.NewProtocol6WithError(New("test")()),
+			"echo":     echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+ephemeral "timeslug_slug" "test" {
+  anchor = "2026-02-03"
+  mode   = "obfuscated"
+  length = 16
+}
+provider "echo" {
+  data = ephemeral.timeslug_slug.test
+}
+resource "echo" "test" {}`,
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("slug"), knownvalue.StringExact("trybeambold8")),
+				statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("period"), knownvalue.StringExact("2026-02-03")),
+			},
+		}},
+	})
+}
@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
+)
+
+var (
+	_ function.Function = &slugFunction{}
+	_ function.Function = &currentFunction{}
+	_ function.Function = &windowFunction{}
+	_ function.Function = &verifyFunction{}
+)
+
+// Parameters shared by the slug functions.
+var (
+	seedParameter = function.StringParameter{
+		Name:        "seed",
+		Description: "Secret seed for slug generation.",
+	}
+	anchorParameter = function.StringParameter{
+		Name:        "anchor",
+		Description: "Point in time (e.g., 2006-01-02 or 2006-01-02T15:04:05).",
+	}
+	intervalParameter = function.StringParameter{
+		Name:        "interval",
+		Description: "Rotation interval: second, minute, hour, day, week, month, quarter, year, or a multiple such as 15m, 6h or 2w.",
+	}
+	modeParameter = function.StringParameter{
+		Name:        "mode",
+		Description: "Output mode: bip39 (words) or obfuscated (alphanumeric).",
+	}
+	lengthParameter = function.Int64Parameter{
+		Name:        "length",
+		Description: "Slug length: words (1-24) for bip39, characters for obfuscated.",
+	}
+)
+
+// slugFunction derives the slug for an explicit period string.
+type slugFunction struct{}
+
+func NewSlugFunction() function.Function {
+	return &slugFunction{}
+}
+
+func (f *slugFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "slug"
+}
+
+func (f *slugFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Derive the slug for a period",
+		Description: "Returns the slug, period and hash derived from seed for the given period string.",
+		Parameters: []function.Parameter{
+			seedParameter,
+			function.StringParameter{
+				Name:        "period",
+				Description: "Period string as produced by timeslug_slugs (e.g., 2026-02-03).",
+			},
+			modeParameter,
+			lengthParameter,
+		},
+		Return: function.ObjectReturn{AttributeTypes: slugAttrTypes},
+	}
+}
+
+func (f *slugFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed, period, mode string
+	var length int64
+	resp.Error = req.Arguments.Get(ctx, &seed, &period, &mode, &length)
+	if resp.Error != nil {
+		return
+	}
+
+	g, err := timeslug.New(seed, timeslug.WithLength(int(length)), timeslug.WithMode(mode))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	obj, diags := slugValue(g.Derive(period), false)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, obj)
+}
+
+// currentFunction derives the slug for the period containing anchor.
+type currentFunction struct{}
+
+func NewCurrentFunction() function.Function {
+	return &currentFunction{}
+}
+
+func (f *currentFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "current"
+}
+
+func (f *currentFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Derive the slug for the period containing anchor",
+		Description: "Returns the slug, period and hash for the rotation period that contains anchor.",
+		Parameters:  []function.Parameter{seedParameter, anchorParameter, intervalParameter, modeParameter, lengthParameter},
+		Return:      function.ObjectReturn{AttributeTypes: slugAttrTypes},
+	}
+}
+
+func (f *currentFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed, anchor, interval, mode string
+	var length int64
+	resp.Error = req.Arguments.Get(ctx, &seed, &anchor, &interval, &mode, &length)
+	if resp.Error != nil {
+		return
+	}
+
+	slugs, err := timeslug.Generate(seed, anchor, int(length), 1, interval, mode)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	obj, diags := slugValue(slugs[0], false)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, obj)
+}
+
+// windowFunction derives the slugs for a window of periods centered on anchor.
+type windowFunction struct{}
+
+func NewWindowFunction() function.Function {
+	return &windowFunction{}
+}
+
+func (f *windowFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "window"
+}
+
+func (f *windowFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Derive slugs for a rolling time window",
+		Description: "Returns the same list of slugs as the timeslug_slugs data source for the given arguments.",
+		Parameters: []function.Parameter{
+			seedParameter,
+			anchorParameter,
+			function.Int64Parameter{
+				Name:        "window",
+				Description: "Number of periods in the window.",
+			},
+			intervalParameter,
+			modeParameter,
+			lengthParameter,
+		},
+		Return: function.ListReturn{ElementType: types.ObjectType{AttrTypes: slugAttrTypes}},
+	}
+}
+
+func (f *windowFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed, anchor, interval, mode string
+	var window, length int64
+	resp.Error = req.Arguments.Get(ctx, &seed, &anchor, &window, &interval, &mode, &length)
+	if resp.Error != nil {
+		return
+	}
+	if window < 1 {
+		resp.Error = function.NewArgumentFuncError(2, "window must be at least 1")
+		return
+	}
+
+	slugs, err := timeslug.Generate(seed, anchor, int(length), int(window), interval, mode)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	list, diags := slugsValue(slugs, false)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, list)
+}
+
+// verifyFunction checks a slug against the periods around anchor.
+type verifyFunction struct{}
+
+func NewVerifyFunction() function.Function {
+	return &verifyFunction{}
+}
+
+func (f *verifyFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "verify"
+}
+
+func (f *verifyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Verify a slug against the periods around anchor",
+		Description: "Returns whether slug belongs to the period containing anchor or to one of the tolerance periods before or after it, and which one.",
+		Parameters: []function.Parameter{
+			seedParameter,
+			function.StringParameter{
+				Name:        "slug",
+				Description: "Slug to verify.",
+			},
+			anchorParameter,
+			function.Int64Parameter{
+				Name:        "tolerance",
+				Description: "Number of periods before and after anchor that are also accepted.",
+			},
+			intervalParameter,
+			modeParameter,
+			lengthParameter,
+		},
+		Return: function.ObjectReturn{AttributeTypes: matchAttrTypes},
+	}
+}
+
+func (f *verifyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed, slug, anchor, interval, mode string
+	var tolerance, length int64
+	resp.Error = req.Arguments.Get(ctx, &seed, &slug, &anchor, &tolerance, &interval, &mode, &length)
+	if resp.Error != nil {
+		return
+	}
+
+	at, err := timeslug.ParseTime(anchor)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+	match, err := timeslug.Verify(seed, slug, at, int(tolerance), interval, mode, int(length))
+	if err != nil && !errors.Is(err, timeslug.ErrNoMatch) {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	obj, diags := matchValue(match, err == nil)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, obj)
+}
+
+// matchAttrTypes is the object shape of a verification result.
+var matchAttrTypes = map[string]attr.Type{
+	"valid":  types.BoolType,
+	"period": types.StringType,
+	"offset": types.Int64Type,
+}
+
+func matchValue(m timeslug.Match, valid bool) (types.Object, diag.Diagnostics) {
+	if !valid {
+		return types.ObjectValue(matchAttrTypes, map[string]attr.Value{
+			"valid":  types.BoolValue(false),
+			"period": types.StringNull(),
+			"offset": types.Int64Null(),
+		})
+	}
+	return types.ObjectValue(matchAttrTypes, map[string]attr.Value{
+		"valid":  types.BoolValue(true),
+		"period": types.StringValue(m.Period),
+		"offset": types.Int64Value(int64(m.Offset)),
+	})
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) *function.RunResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	defResp := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, defResp)
+	if defResp.Diagnostics.HasError() {
+		t.Fatal(defResp.Diagnostics)
+	}
+	if len(defResp.Definition.Parameters) != len(args) {
+		t.Fatalf("expected %d parameters, got %d", len(args), len(defResp.Definition.Parameters))
+	}
+
+	result, funcErr := defResp.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	resp := &function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp
+}
+
+func slugAttr(t *testing.T, v attr.Value, name string) string {
+	t.Helper()
+	obj, ok := v.(types.Object)
+	if !ok {
+		t.Fatalf("expected object, got %T", v)
+	}
+	return obj.Attributes()[name].(types.String).ValueString()
+}
+
+func TestFunctions(t *testing.T) {
+	ctx := context.Background()
+	names := map[string]function.Function{
+		"slug":    NewSlugFunction(),
+		"current": NewCurrentFunction(),
+		"window":  NewWindowFunction(),
+		"verify":  NewVerifyFunction(),
+	}
+	for want, f := range names {
+		metaResp := &function.MetadataResponse{}
+		f.Metadata(ctx, function.MetadataRequest{}, metaResp)
+		if metaResp.Name != want {
+			t.Errorf("got name=%q, want %q", metaResp.Name, want)
+		}
+	}
+
+	// slug
+	resp := runFunction(t, NewSlugFunction(),
+		types.StringValue("seedphrase"), types.StringValue("2026-02-03"), types.StringValue("obfuscated"), types.Int64Value(16))
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+	if got := slugAttr(t, resp.Result.Value(), "slug"); got != "trybeambold8" {
+		t.Errorf("slug: got %q", got)
+	}
+
+	// current
+	resp = runFunction(t, NewCurrentFunction(),
+		types.StringValue("seedphrase"), types.StringValue("2026-02-03T15:04:05"), types.StringValue("day"), types.StringValue("bip39"), types.Int64Value(3))
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+	if got := slugAttr(t, resp.Result.Value(), "period"); got != "2026-02-03" {
+		t.Errorf("current: got period %q", got)
+	}
+	if got := slugAttr(t, resp.Result.Value(), "slug"); got != "exoticangryanswer" {
+		t.Errorf("current: got slug %q", got)
+	}
+
+	// window
+	resp = runFunction(t, NewWindowFunction(),
+		types.StringValue("seedphrase"), types.StringValue("2026-02-03"), types.Int64Value(3), types.StringValue("day"), types.StringValue("bip39"), types.Int64Value(3))
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+	list, ok := resp.Result.Value().(types.List)
+	if !ok || len(list.Elements()) != 3 {
+		t.Fatalf("window: got %v", resp.Result.Value())
+	}
+	if got := slugAttr(t, list.Elements()[1], "slug"); got != "exoticangryanswer" {
+		t.Errorf("window: got center slug %q", got)
+	}
+
+	// verify
+	resp = runFunction(t, NewVerifyFunction(),
+		types.StringValue("seedphrase"), types.StringValue("exoticangryanswer"), types.StringValue("2026-02-04"), types.Int64Value(1), types.StringValue("day"), types.StringValue("bip39"), types.Int64Value(3))
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+	match := resp.Result.Value().(types.Object).Attributes()
+	if !match["valid"].(types.Bool).ValueBool() || match["offset"].(types.Int64).ValueInt64() != -1 {
+		t.Errorf("verify: got %v", match)
+	}
+	resp = runFunction(t, NewVerifyFunction(),
+		types.StringValue("seedphrase"), types.StringValue("exoticangryanswer"), types.StringValue("2026-02-04"), types.Int64Value(0), types.StringValue("day"), types.StringValue("bip39"), types.Int64Value(3))
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+	match = resp.Result.Value().(types.Object).Attributes()
+	if match["valid"].(types.Bool).ValueBool() || !match["period"].IsNull() {
+		t.Errorf("verify: got %v", match)
+	}
+
+	// Errors
+	resp = runFunction(t, NewWindowFunction(),
+		types.StringValue("seed"), types.StringValue("2026-02-03"), types.Int64Value(-1), types.StringValue("day"), types.StringValue("bip39"), types.Int64Value(3))
+	if resp.Error == nil {
+		t.Error("expected error for negative window")
+	}
+	resp = runFunction(t, NewCurrentFunction(),
+		types.StringValue("seed"), types.StringValue("invalid"), types.StringValue("day"), types.StringValue("bip39"), types.Int64Value(3))
+	if resp.Error == nil {
+		t.Error("expected error for invalid anchor")
+	}
+	resp = runFunction(t, NewSlugFunction(),
+		types.StringValue("seed"), types.StringValue("2026-02-03"), types.StringValue("bip39"), types.Int64Value(25))
+	if resp.Error == nil {
+		t.Error("expected error for more than 24 words")
+	}
+}
+
+// Acceptance tests
+func TestAccFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+
+output "slug" {
+  value = provider::timeslug::slug("seedphrase", "2026-02-03", "obfuscated", 16).slug
+}
+output "current" {
+  value = provider::timeslug::current("seedphrase", "2026-02-03T12:00", "day", "bip39", 3).slug
+}
+output "verify" {
+  value = provider::timeslug::verify("seedphrase", "exoticangryanswer", "2026-02-04", 1, "day", "bip39", 3).period
+}
+output "window" {
+  value = length(provider::timeslug::window("seedphrase", "2026-02-03", 5, "day", "bip39", 3))
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckOutput("slug", "trybeambold8"),
+				resource.TestCheckOutput("current", "exoticangryanswer"),
+				resource.TestCheckOutput("verify", "2026-02-03"),
+				resource.TestCheckOutput("window", "5"),
+			),
+		}},
+	})
+}
@@ -0,0 +1,357 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
+)
+
+// Attribute descriptions shared by every schema that derives slugs.
+const (
+	lengthDescription       = "Slug length: words for bip39 (1-256, 1-24 with algorithm_version 1), characters for obfuscated (1-1024). Default: the provider's length, or 3"
+	intervalDescription     = "Rotation interval: second, minute, hour, day, week, month, quarter, year, or a multiple such as 15m, 6h or 2w. Default: the provider's interval, or day"
+	isoWeekDescription      = "Use ISO 8601 week periods (2026-W06) rotating on Monday for the week interval. Default: false"
+	epochDescription        = "Time multiples such as 15m are counted from (e.g., 2024-01-01). Default: 1970-01-01"
+	timezoneDescription     = "IANA time zone periods are computed in (e.g., America/New_York). Default: the provider's timezone, or UTC"
+	modeDescription         = "Output mode: bip39 (words) or obfuscated (alphanumeric). Default: the provider's mode, or bip39"
+	languageDescription     = "BIP39 wordlist language for bip39 mode (e.g., english). Default: english"
+	separatorDescription    = "String joining the words of bip39 slugs (e.g., -). Default: an ideographic space for japanese, otherwise none"
+	caseDescription         = "Case of the words of bip39 slugs: lower, title, camel or upper. Default: lower, or the words as given with a custom wordlist"
+	wordlistDescription     = "Custom wordlist replacing the BIP39 wordlist in bip39 mode: at least two distinct words, each equally likely. Conflicts with wordlist_file"
+	wordlistFileDescription = "Path of a custom wordlist file with one word per line; blank lines and lines starting with # are skipped. Conflicts with wordlist"
+	namespaceDescription    = "Namespace mixed into derivation so services sharing a seed get independent slugs (e.g., api). Requires algorithm_version 3. Default: none"
+	versionDescription      = "Algorithm version: 1 (obfuscated slugs are 10-18 characters) 2 (obfuscated slugs are exactly length characters) or 3 (version 2 derived with HKDF). Default: the provider's algorithm_version, or 1"
+)
+
+// generatorModel holds the generation attributes shared by data sources,
+// resources and ephemeral resources.
+type generatorModel struct {
+	Length       types.Int64  `tfsdk:"length"`
+	Interval     types.String `tfsdk:"interval"`
+	ISOWeek      types.Bool   `tfsdk:"iso_week"`
+	Epoch        types.String `tfsdk:"epoch"`
+	Timezone     types.String `tfsdk:"timezone"`
+	Mode         types.String `tfsdk:"mode"`
+	Language     types.String `tfsdk:"language"`
+	Separator    types.String `tfsdk:"separator"`
+	Case         types.String `tfsdk:"case"`
+	Wordlist     types.List   `tfsdk:"wordlist"`
+	WordlistFile types.String `tfsdk:"wordlist_file"`
+	Namespace    types.String `tfsdk:"namespace"`
+	Version      types.Int64  `tfsdk:"algorithm_version"`
+}
+
+// length returns the configured length, or the provider's default if unset.
+func (m generatorModel) length(p providerData) int {
+	if m.Length.IsNull() || m.Length.IsUnknown() {
+		return p.defaultLength()
+	}
+	return int(m.Length.ValueInt64())
+}
+
+// interval returns the configured interval, or the provider's default if
+// unset.
+func (m generatorModel) interval(p providerData) string {
+	if m.Interval.IsNull() || m.Interval.IsUnknown() {
+		return p.defaultInterval()
+	}
+	return m.Interval.ValueString()
+}
+
+// timezone returns the configured timezone, or the provider's default if
+// unset.
+func (m generatorModel) timezone(p providerData) string {
+	if m.Timezone.IsNull() || m.Timezone.IsUnknown() {
+		return p.defaultTimezone()
+	}
+	return m.Timezone.ValueString()
+}
+
+func (m generatorModel) language() string {
+	if m.Language.IsNull() || m.Language.IsUnknown() {
+		return timeslug.DefaultLanguage
+	}
+	return m.Language.ValueString()
+}
+
+// separator returns the configured separator, or the default separator of
+// the language if unset.
+func (m generatorModel) separator() string {
+	if m.Separator.IsNull() || m.Separator.IsUnknown() {
+		if !m.Wordlist.IsNull() || !m.WordlistFile.IsNull() {
+			return ""
+		}
+		return timeslug.DefaultSeparator(m.language())
+	}
+	return m.Separator.ValueString()
+}
+
+// wordCase returns the configured case, or an empty string if unset: the
+// engine default, which keeps the words of a custom wordlist as given.
+func (m generatorModel) wordCase() string {
+	if m.Case.IsNull() || m.Case.IsUnknown() {
+		return ""
+	}
+	return m.Case.ValueString()
+}
+
+// wordlist returns the custom wordlist, nil if neither wordlist nor
+// wordlist_file is set.
+func (m generatorModel) wordlist() ([]string, error) {
+	switch {
+	case !m.Wordlist.IsNull() && !m.WordlistFile.IsNull():
+		return nil, fmt.Errorf("%w: wordlist and wordlist_file are mutually exclusive", timeslug.ErrInvalidWordlist)
+	case !m.WordlistFile.IsNull():
+		return timeslug.LoadWordlist(m.WordlistFile.ValueString())
+	case !m.Wordlist.IsNull():
+		words := []string{}
+		for _, v := range m.Wordlist.Elements() {
+			if s, ok := v.(types.String); ok {
+				words = append(words, s.ValueString())
+			}
+		}
+		return words, nil
+	}
+	return nil, nil
+}
+
+// version returns the configured algorithm version, or def if unset.
+func (m generatorModel) version(def int) int {
+	if m.Version.IsNull() || m.Version.IsUnknown() {
+		return def
+	}
+	return int(m.Version.ValueInt64())
+}
+
+// mode returns the configured mode, or the provider's default if unset.
+func (m generatorModel) mode(p providerData) string {
+	if m.Mode.IsNull() || m.Mode.IsUnknown() {
+		return p.defaultMode()
+	}
+	return m.Mode.ValueString()
+}
+
+// unknownAttribute returns the name of a generation attribute, or of extra,
+// that is unknown at plan time, or an empty string if all are known.
+func (m generatorModel) unknownAttribute(extra map[string]attr.Value) string {
+	if slices.ContainsFunc(m.Wordlist.Elements(), attr.Value.IsUnknown) {
+		return "wordlist"
+	}
+	values := map[string]attr.Value{"length": m.Length, "interval": m.Interval, "iso_week": m.ISOWeek, "epoch": m.Epoch,
+		"timezone": m.Timezone, "mode": m.Mode, "language": m.Language, "separator": m.Separator, "case": m.Case,
+		"wordlist": m.Wordlist, "wordlist_file": m.WordlistFile, "namespace": m.Namespace, "algorithm_version": m.Version}
+	maps.Copy(values, extra)
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if values[name].IsUnknown() {
+			return name
+		}
+	}
+	return ""
+}
+
+// addUnknownError adds the error of a read or open that cannot be deferred:
+// on the unknown attribute, or on the provider configuration if unknown.
+func addUnknownError(diags *diag.Diagnostics, providerUnknown bool, attribute string) {
+	if providerUnknown {
+		diags.AddError("Config Error", "the provider configuration is unknown at plan time and the client does not support deferred actions: "+
+			"derive the provider arguments from known values")
+		return
+	}
+	diags.AddAttributeError(path.Root(attribute), "Config Error",
+		attribute+" is unknown at plan time and the client does not support deferred actions: derive it from known values")
+}
+
+// generatorKey identifies the generators of a provider's generatorCache by
+// the resolved generation attributes.
+type generatorKey struct {
+	length           int
+	interval         string
+	isoWeek          bool
+	epoch            string
+	timezone         string
+	mode             string
+	language         string
+	words            string
+	custom           bool
+	separator, wcase string
+	namespace        string
+	version          int
+}
+
+// generator returns the Generator configured by the model and the provider,
+// from the provider's cache if another configuration built it before.
+func (m generatorModel) generator(p providerData) (*timeslug.Generator, error) {
+	words, err := m.wordlist()
+	if err != nil {
+		return nil, err
+	}
+	key := generatorKey{
+		length:    m.length(p),
+		interval:  m.interval(p),
+		isoWeek:   m.ISOWeek.ValueBool(),
+		epoch:     m.Epoch.ValueString(),
+		timezone:  m.timezone(p),
+		mode:      m.mode(p),
+		language:  m.language(),
+		words:     strings.Join(words, "\n"),
+		custom:    words != nil,
+		separator: m.separator(),
+		wcase:     m.wordCase(),
+		namespace: m.Namespace.ValueString(),
+		version:   m.version(p.defaultVersion()),
+	}
+	return p.cache.get(key, func() (*timeslug.Generator, error) {
+		loc, err := timeslug.LoadLocation(key.timezone)
+		if err != nil {
+			return nil, err
+		}
+		epoch := time.Unix(0, 0)
+		if !m.Epoch.IsNull() {
+			if epoch, err = timeslug.ParseTimeIn(key.epoch, loc); err != nil {
+				return nil, fmt.Errorf("epoch: %w", err)
+			}
+		}
+		opts := []timeslug.Option{
+			timeslug.WithLength(key.length),
+			timeslug.WithInterval(key.interval),
+			timeslug.WithISOWeek(key.isoWeek),
+			timeslug.WithEpoch(epoch),
+			timeslug.WithLocation(loc),
+			timeslug.WithMode(key.mode),
+			timeslug.WithLanguage(key.language),
+			timeslug.WithWordlist(words),
+			timeslug.WithSeparator(key.separator),
+			timeslug.WithNamespace(key.namespace),
+			timeslug.WithVersion(key.version),
+		}
+		if key.wcase != "" {
+			opts = append(opts, timeslug.WithCase(key.wcase))
+		}
+		if p.seeds != nil {
+			opts = append(opts, timeslug.WithSeeds(p.seeds...))
+		}
+		return timeslug.New(p.seed, opts...)
+	})
+}
+
+// id identifies a data source reading g at anchor. last is the trailing
+// field, such as the window of timeslug_slugs.
+func (m generatorModel) id(p providerData, g *timeslug.Generator, anchor string, last int64) string {
+	id := fmt.Sprintf("%s-%s-%s-%d-%d", anchor, m.mode(p), m.interval(p), m.length(p), last)
+	if ns := g.Namespace(); ns != "" {
+		id += "-" + ns
+	}
+	if digest := g.WordlistDigest(); digest != "" {
+		// Edits to a custom wordlist show up in plans.
+		id += "-" + digest
+	}
+	return id
+}
+
+// known reports whether a configuration value is set and known.
+func known(v attr.Value) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
+
+// validate adds diagnostics for combinations of attributes that are valid on
+// their own but not together. Values unknown at plan time are skipped.
+func (m generatorModel) validate(diags *diag.Diagnostics) {
+	custom := !m.Wordlist.IsNull() || !m.WordlistFile.IsNull()
+	if !m.Wordlist.IsNull() && !m.WordlistFile.IsNull() {
+		diags.AddAttributeError(path.Root("wordlist_file"), "Config Error", "wordlist_file conflicts with wordlist, set only one of them")
+	}
+	if custom && known(m.Language) {
+		diags.AddAttributeWarning(path.Root("language"), "Ignored Attribute", "language has no effect with a custom wordlist")
+	}
+	if known(m.Interval) {
+		interval := m.Interval.ValueString()
+		if g, err := timeslug.New("", timeslug.WithInterval(interval), timeslug.WithISOWeek(true)); err == nil {
+			if known(m.ISOWeek) && m.ISOWeek.ValueBool() && !g.ISOWeek() {
+				diags.AddAttributeWarning(path.Root("iso_week"), "Ignored Attribute",
+					fmt.Sprintf("iso_week has no effect with interval %q, only with week", interval))
+			}
+			if known(m.Epoch) && !g.Multiple() {
+				diags.AddAttributeWarning(path.Root("epoch"), "Ignored Attribute",
+					fmt.Sprintf("epoch has no effect with interval %q, only with multiples such as 15m or 2w", interval))
+			}
+		}
+	}
+	if known(m.Namespace) && m.Namespace.ValueString() != "" && known(m.Version) && m.Version.ValueInt64() != timeslug.Version3 {
+		diags.AddAttributeError(path.Root("namespace"), "Config Error",
+			fmt.Sprintf("namespace requires algorithm_version = %d, got %d", timeslug.Version3, m.Version.ValueInt64()))
+	}
+
+	// Without mode, the provider's mode applies, which is not known before
+	// the provider is configured.
+	if !known(m.Mode) {
+		return
+	}
+	if strings.EqualFold(m.Mode.ValueString(), timeslug.ModeObfuscated) {
+		if known(m.Separator) {
+			diags.AddAttributeWarning(path.Root("separator"), "Ignored Attribute", "separator has no effect in obfuscated mode")
+		}
+		if known(m.Case) {
+			diags.AddAttributeWarning(path.Root("case"), "Ignored Attribute", "case has no effect in obfuscated mode")
+		}
+		return
+	}
+	if known(m.Length) && m.Length.ValueInt64() > timeslug.MaxWords {
+		diags.AddAttributeError(path.Root("length"), "Config Error",
+			fmt.Sprintf("bip39 slugs are at most %d words, got %d", timeslug.MaxWords, m.Length.ValueInt64()))
+	} else if !custom && known(m.Length) && known(m.Version) && m.Version.ValueInt64() == timeslug.Version1 && m.Length.ValueInt64() > 24 {
+		diags.AddAttributeError(path.Root("length"), "Config Error",
+			fmt.Sprintf("algorithm_version 1 derives at most 24 bip39 words, got %d: set algorithm_version = 2 for longer slugs", m.Length.ValueInt64()))
+	}
+}
+
+// errorPaths maps generation errors to the attribute that caused them.
+var errorPaths = []struct {
+	err  error
+	attr string
+}{
+	{timeslug.ErrInvalidLength, "length"},
+	{timeslug.ErrInvalidInterval, "interval"},
+	{timeslug.ErrInvalidTimezone, "timezone"},
+	{timeslug.ErrInvalidMode, "mode"},
+	{timeslug.ErrInvalidLanguage, "language"},
+	{timeslug.ErrInvalidCase, "case"},
+	{timeslug.ErrInvalidWordlist, "wordlist"},
+	{timeslug.ErrInvalidNamespace, "namespace"},
+	{timeslug.ErrInvalidVersion, "algorithm_version"},
+	{timeslug.ErrInvalidWindow, "window"},
+	{timeslug.ErrInvalidTolerance, "tolerance"},
+}
+
+// addError adds err to diags, on the attribute of the configuration m that
+// caused it if known. Values m inherits from the provider configuration
+// have no path in m, so their errors name the provider instead.
+func (m generatorModel) addError(diags *diag.Diagnostics, summary string, err error) {
+	inherited := map[string]bool{
+		"length":            m.Length.IsNull(),
+		"interval":          m.Interval.IsNull(),
+		"timezone":          m.Timezone.IsNull(),
+		"mode":              m.Mode.IsNull(),
+		"algorithm_version": m.Version.IsNull(),
+	}
+	for _, e := range errorPaths {
+		if !errors.Is(err, e.err) {
+			continue
+		}
+		if inherited[e.attr] {
+			diags.AddError(summary, fmt.Sprintf("%s: %s comes from the provider configuration", err, e.attr))
+			return
+		}
+		diags.AddAttributeError(path.Root(e.attr), summary, err.Error())
+		return
+	}
+	diags.AddError(summary, err.Error())
+}
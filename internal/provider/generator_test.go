@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
+)
+
+func TestGeneratorModel(t *testing.T) {
+	// Null attributes fall back to the provider defaults, then the engine
+	// defaults.
+	var m generatorModel
+	if m.length(providerData{}) != 3 || m.interval(providerData{}) != "day" || m.mode(providerData{}) != "bip39" {
+		t.Errorf("got %d/%q/%q", m.length(providerData{}), m.interval(providerData{}), m.mode(providerData{}))
+	}
+	defaults := providerData{length: 16, interval: "week", mode: "obfuscated", timezone: "America/New_York"}
+	if m.length(defaults) != 16 || m.interval(defaults) != "week" || m.mode(defaults) != "obfuscated" || m.timezone(defaults) != "America/New_York" {
+		t.Errorf("got %d/%q/%q/%q", m.length(defaults), m.interval(defaults), m.mode(defaults), m.timezone(defaults))
+	}
+	if m := (generatorModel{Length: types.Int64Value(5), Mode: types.StringValue("bip39")}); m.length(defaults) != 5 || m.mode(defaults) != "bip39" {
+		t.Errorf("attributes should override provider defaults, got %d/%q", m.length(defaults), m.mode(defaults))
+	}
+	g, err := m.generator(providerData{seed: "seedphrase"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "exoticangryanswer" {
+		t.Errorf("got %q", got)
+	}
+
+	// Anchors without an offset are wall-clock times in the time zone.
+	m = generatorModel{Timezone: types.StringValue("America/New_York")}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	anchor, err := g.ParseTime("2026-02-03T23:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.At(anchor).Period; got != "2026-02-03" {
+		t.Errorf("got %q", got)
+	}
+
+	// Without epoch, multiples are counted from the Unix epoch in any time
+	// zone, as in the CLI (cmd/timeslug TestRun).
+	m = generatorModel{Interval: types.StringValue("6h"), Timezone: types.StringValue("America/New_York")}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if s := g.At(time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)); s.Period != "2026-02-03T12/6h" || s.Value != "pentradeimpulse" {
+		t.Errorf("got %s %s", s.Period, s.Value)
+	}
+
+	m = generatorModel{Mode: types.StringValue("obfuscated"), Length: types.Int64Value(8), Version: types.Int64Value(2)}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "trybeamb" {
+		t.Errorf("got %q", got)
+	}
+
+	// The provider's algorithm_version applies unless the model sets one.
+	m = generatorModel{Mode: types.StringValue("obfuscated"), Length: types.Int64Value(8)}
+	if g, err = m.generator(providerData{seed: "seedphrase", version: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "trybeamb" {
+		t.Errorf("got %q", got)
+	}
+	m.Version = types.Int64Value(1)
+	if g, err = m.generator(providerData{seed: "seedphrase", version: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "trybeambold8" {
+		t.Errorf("got %q", got)
+	}
+
+	// Japanese words are joined by the ideographic space unless separator
+	// is set.
+	m = generatorModel{Language: types.StringValue("japanese")}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; strings.Count(got, "\u3000") != 2 {
+		t.Errorf("got %q", got)
+	}
+	m.Separator = types.StringValue("")
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; strings.Contains(got, "\u3000") {
+		t.Errorf("got %q", got)
+	}
+
+	m = generatorModel{Language: types.StringValue("english"), Separator: types.StringValue("-")}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "exotic-angry-answer" {
+		t.Errorf("got %q", got)
+	}
+	m.Case = types.StringValue("title")
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "Exotic-Angry-Answer" {
+		t.Errorf("got %q", got)
+	}
+
+	m = generatorModel{Timezone: types.StringValue("Nowhere/Special")}
+	if _, err := m.generator(providerData{seed: "seed"}); !errors.Is(err, timeslug.ErrInvalidTimezone) {
+		t.Errorf("expected ErrInvalidTimezone, got %v", err)
+	}
+	// Custom wordlists, inline or from a file.
+	words := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("red"), types.StringValue("green"), types.StringValue("blue"), types.StringValue("gold"),
+	})
+	m = generatorModel{Wordlist: words, Length: types.Int64Value(4), Separator: types.StringValue("-")}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "green-green-red-red" {
+		t.Errorf("got %q", got)
+	}
+	// Custom words keep their case unless case is set.
+	brands := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("Acme"), types.StringValue("NASA"), types.StringValue("red"), types.StringValue("Blue"),
+	})
+	m = generatorModel{Wordlist: brands, Length: types.Int64Value(4), Separator: types.StringValue("-")}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "NASA-NASA-Acme-Acme" {
+		t.Errorf("got %q", got)
+	}
+	m.Case = types.StringValue("lower")
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "nasa-nasa-acme-acme" {
+		t.Errorf("got %q", got)
+	}
+	file := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(file, []byte("red\ngreen\nblue\ngold\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m = generatorModel{WordlistFile: types.StringValue(file), Wordlist: types.ListNull(types.StringType), Length: types.Int64Value(4), Separator: types.StringValue("-")}
+	if g, err = m.generator(providerData{seed: "seedphrase"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "green-green-red-red" {
+		t.Errorf("got %q", got)
+	}
+	m.Wordlist = words
+	if _, err := m.generator(providerData{seed: "seed"}); !errors.Is(err, timeslug.ErrInvalidWordlist) {
+		t.Errorf("expected ErrInvalidWordlist, got %v", err)
+	}
+
+	m = generatorModel{Language: types.StringValue("klingon")}
+	if _, err := m.generator(providerData{seed: "seed"}); !errors.Is(err, timeslug.ErrInvalidLanguage) {
+		t.Errorf("expected ErrInvalidLanguage, got %v", err)
+	}
+	m = generatorModel{Epoch: types.StringValue("yesterday")}
+	if _, err := m.generator(providerData{seed: "seed"}); !errors.Is(err, timeslug.ErrInvalidTime) {
+		t.Errorf("expected ErrInvalidTime, got %v", err)
+	}
+}
+
+func TestGeneratorModelID(t *testing.T) {
+	p := providerData{seed: "seedphrase"}
+	cases := []struct {
+		m    generatorModel
+		want string
+	}{
+		{generatorModel{}, "2026-02-03-bip39-day-3-7"},
+		{generatorModel{Namespace: types.StringValue("api"), Version: types.Int64Value(3)}, "2026-02-03-bip39-day-3-7-api"},
+		{generatorModel{Wordlist: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("red"), types.StringValue("green"), types.StringValue("blue"), types.StringValue("gold")})}, "2026-02-03-bip39-day-3-7-480591bfe8fa04e5"},
+	}
+	for _, tc := range cases {
+		g, err := tc.m.generator(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tc.m.id(p, g, "2026-02-03", 7); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestGeneratorCache(t *testing.T) {
+	p := providerData{seed: "seedphrase", cache: &generatorCache{}}
+	a, err := generatorModel{}.generator(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Null attributes and the defaults they resolve to share a generator.
+	b, err := generatorModel{Length: types.Int64Value(3), Mode: types.StringValue("bip39")}.generator(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Error("expected the cached generator")
+	}
+	c, err := generatorModel{Length: types.Int64Value(4)}.generator(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c == a || len(p.cache.generators) != 2 {
+		t.Errorf("expected a new generator, got %d cached", len(p.cache.generators))
+	}
+	// Errors are not cached.
+	if _, err := (generatorModel{Length: types.Int64Value(0)}).generator(p); err == nil || len(p.cache.generators) != 2 {
+		t.Errorf("got %v with %d cached", err, len(p.cache.generators))
+	}
+}
+
+func TestGeneratorModelUnknownAttribute(t *testing.T) {
+	words := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("red"), types.StringUnknown()})
+	cases := []struct {
+		m     generatorModel
+		extra map[string]attr.Value
+		want  string
+	}{
+		{generatorModel{}, map[string]attr.Value{"anchor": types.StringValue("2026-02-03")}, ""},
+		{generatorModel{}, map[string]attr.Value{"anchor": types.StringUnknown()}, "anchor"},
+		{generatorModel{Mode: types.StringUnknown()}, nil, "mode"},
+		{generatorModel{Wordlist: words}, nil, "wordlist"},
+	}
+	for _, tc := range cases {
+		if got := tc.m.unknownAttribute(tc.extra); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestGeneratorModelValidate(t *testing.T) {
+	list := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("red"), types.StringValue("green")})
+	cases := []struct {
+		name             string
+		m                generatorModel
+		errors, warnings int
+	}{
+		{"defaults", generatorModel{}, 0, 0},
+		{"wordlist conflict", generatorModel{Wordlist: list, WordlistFile: types.StringValue("words.txt")}, 1, 0},
+		{"language with wordlist", generatorModel{Wordlist: list, Language: types.StringValue("english")}, 0, 1},
+		{"obfuscated separator and case", generatorModel{Mode: types.StringValue("obfuscated"), Separator: types.StringValue("-"), Case: types.StringValue("title")}, 0, 2},
+		{"v1 long bip39", generatorModel{Length: types.Int64Value(25), Version: types.Int64Value(1), Mode: types.StringValue("bip39")}, 1, 0},
+		{"v1 long provider mode", generatorModel{Length: types.Int64Value(25), Version: types.Int64Value(1)}, 0, 0},
+		{"v2 long bip39", generatorModel{Length: types.Int64Value(25), Version: types.Int64Value(2)}, 0, 0},
+		{"v1 long custom", generatorModel{Length: types.Int64Value(25), Version: types.Int64Value(1), Wordlist: list}, 0, 0},
+		{"v1 long obfuscated", generatorModel{Length: types.Int64Value(25), Version: types.Int64Value(1), Mode: types.StringValue("obfuscated")}, 0, 0},
+		{"long bip39", generatorModel{Length: types.Int64Value(257), Version: types.Int64Value(1), Mode: types.StringValue("bip39")}, 1, 0},
+		{"long custom", generatorModel{Length: types.Int64Value(257), Wordlist: list, Mode: types.StringValue("bip39")}, 1, 0},
+		{"long obfuscated", generatorModel{Length: types.Int64Value(1024), Mode: types.StringValue("obfuscated")}, 0, 0},
+		{"unknown mode", generatorModel{Length: types.Int64Value(25), Version: types.Int64Value(1), Mode: types.StringUnknown()}, 0, 0},
+		{"iso_week with week", generatorModel{Interval: types.StringValue("week"), ISOWeek: types.BoolValue(true)}, 0, 0},
+		{"iso_week with day", generatorModel{Interval: types.StringValue("day"), ISOWeek: types.BoolValue(true)}, 0, 1},
+		{"iso_week with 2w", generatorModel{Interval: types.StringValue("2w"), ISOWeek: types.BoolValue(true)}, 0, 1},
+		{"iso_week false", generatorModel{Interval: types.StringValue("day"), ISOWeek: types.BoolValue(false)}, 0, 0},
+		{"iso_week provider interval", generatorModel{ISOWeek: types.BoolValue(true)}, 0, 0},
+		{"epoch with 15m", generatorModel{Interval: types.StringValue("15m"), Epoch: types.StringValue("2024-01-01")}, 0, 0},
+		{"epoch with hour", generatorModel{Interval: types.StringValue("hour"), Epoch: types.StringValue("2024-01-01")}, 0, 1},
+		{"namespace v2", generatorModel{Namespace: types.StringValue("api"), Version: types.Int64Value(2)}, 1, 0},
+		{"namespace v3", generatorModel{Namespace: types.StringValue("api"), Version: types.Int64Value(3)}, 0, 0},
+		{"namespace provider version", generatorModel{Namespace: types.StringValue("api")}, 0, 0},
+	}
+	for _, tc := range cases {
+		var diags diag.Diagnostics
+		tc.m.validate(&diags)
+		if diags.ErrorsCount() != tc.errors || diags.WarningsCount() != tc.warnings {
+			t.Errorf("%s: got %v", tc.name, diags)
+		}
+	}
+}
+
+func TestAddError(t *testing.T) {
+	var diags diag.Diagnostics
+	m := generatorModel{Length: types.Int64Value(500)}
+	m.addError(&diags, "Generation Failed", fmt.Errorf("wrapped: %w", timeslug.ErrInvalidLength))
+	m.addError(&diags, "Generation Failed", errors.New("other"))
+	// An invalid provider default has no path in the data source.
+	m.addError(&diags, "Generation Failed", fmt.Errorf("wrapped: %w", timeslug.ErrInvalidMode))
+	if len(diags) != 3 {
+		t.Fatalf("got %v", diags)
+	}
+	if d, ok := diags[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(path.Root("length")) {
+		t.Errorf("got %v", diags[0])
+	}
+	if _, ok := diags[1].(diag.DiagnosticWithPath); ok {
+		t.Errorf("got %v", diags[1])
+	}
+	if _, ok := diags[2].(diag.DiagnosticWithPath); ok || !strings.HasSuffix(diags[2].Detail(), "mode comes from the provider configuration") {
+		t.Errorf("got %v", diags[2])
+	}
+
+	// A provider length of 500 is too long for bip39 slugs.
+	m = generatorModel{}
+	_, err := m.generator(providerData{seed: "seedphrase", length: 500, mode: "bip39", cache: &generatorCache{}})
+	diags = nil
+	m.addError(&diags, "Generation Failed", err)
+	if len(diags) != 1 {
+		t.Fatalf("got %v", diags)
+	}
+	if _, ok := diags[0].(diag.DiagnosticWithPath); ok || !strings.Contains(diags[0].Detail(), "length comes from the provider configuration") {
+		t.Errorf("got %v", diags)
+	}
+}
@@ -2,22 +2,233 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
 )
 
-var _ provider.Provider = &timeslugProvider{}
+var (
+	_ provider.Provider                       = &timeslugProvider{}
+	_ provider.ProviderWithFunctions          = &timeslugProvider{}
+	_ provider.ProviderWithEphemeralResources = &timeslugProvider{}
+)
 
 type timeslugProvider struct {
 	version string
 }
 
+// seedEnv is the environment variable the seed is read from when neither
+// seed nor seed_file is set.
+const seedEnv = "TIMESLUG_SEED"
+
 type providerModel struct {
-	Seed types.String `tfsdk:"seed"`
+	Seed     types.String `tfsdk:"seed"`
+	SeedFile types.String `tfsdk:"seed_file"`
+	Version  types.Int64  `tfsdk:"algorithm_version"`
+	Length   types.Int64  `tfsdk:"length"`
+	Window   types.Int64  `tfsdk:"window"`
+	Interval types.String `tfsdk:"interval"`
+	Mode     types.String `tfsdk:"mode"`
+	Timezone types.String `tfsdk:"timezone"`
+	Seeds    types.List   `tfsdk:"seeds"`
+}
+
+// seedModel is a block of seeds.
+type seedModel struct {
+	ID         types.String `tfsdk:"id"`
+	Seed       types.String `tfsdk:"seed"`
+	ValidFrom  types.String `tfsdk:"valid_from"`
+	ValidUntil types.String `tfsdk:"valid_until"`
+}
+
+// providerData is passed to data sources, resources and ephemeral
+// resources, which embed it.
+type providerData struct {
+	seed string
+	// seeds are the scheduled seeds of the seeds blocks, nil without them.
+	seeds []timeslug.Seed
+	// version, length, window, interval, mode and timezone are the
+	// defaults set on the provider, zero if unset.
+	version  int
+	length   int
+	window   int
+	interval string
+	mode     string
+	timezone string
+	// cache is shared by every copy of the providerData of a provider, nil
+	// if generators are not cached.
+	cache *generatorCache
+	// unknown is set when the provider configuration is not known yet at
+	// plan time and the client cannot defer.
+	unknown bool
+}
+
+// defaultVersion returns the algorithm version used when a configuration
+// does not set algorithm_version.
+func (p providerData) defaultVersion() int {
+	if p.version == 0 {
+		return timeslug.DefaultVersion
+	}
+	return p.version
+}
+
+// defaultLength returns the length used when a configuration does not set
+// length.
+func (p providerData) defaultLength() int {
+	if p.length == 0 {
+		return timeslug.DefaultLength
+	}
+	return p.length
+}
+
+// defaultWindow returns the window used when a timeslug_slugs data source
+// does not set window.
+func (p providerData) defaultWindow() int {
+	if p.window == 0 {
+		return 7
+	}
+	return p.window
+}
+
+// defaultInterval returns the interval used when a configuration does not
+// set interval.
+func (p providerData) defaultInterval() string {
+	if p.interval == "" {
+		return timeslug.DefaultInterval
+	}
+	return p.interval
+}
+
+// defaultMode returns the mode used when a configuration does not set mode.
+func (p providerData) defaultMode() string {
+	if p.mode == "" {
+		return timeslug.DefaultMode
+	}
+	return p.mode
+}
+
+// defaultTimezone returns the timezone used when a configuration does not
+// set timezone, empty for UTC.
+func (p providerData) defaultTimezone() string {
+	return p.timezone
+}
+
+// generatorCache shares generators between the data sources, resources and
+// ephemeral resources of a provider, which mostly derive slugs with the same
+// few configurations. Generators are immutable, so sharing them is safe.
+type generatorCache struct {
+	mu         sync.Mutex
+	generators map[generatorKey]*timeslug.Generator
+}
+
+// get returns the cached generator for key, calling build on a miss. Errors
+// are not cached.
+func (c *generatorCache) get(key generatorKey, build func() (*timeslug.Generator, error)) (*timeslug.Generator, error) {
+	if c == nil {
+		return build()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if g, ok := c.generators[key]; ok {
+		return g, nil
+	}
+	g, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if c.generators == nil {
+		c.generators = map[generatorKey]*timeslug.Generator{}
+	}
+	c.generators[key] = g
+	return g, nil
+}
+
+// seed returns the seed from seed, seed_file or the TIMESLUG_SEED
+// environment variable, in that order. Errors come with the attribute that
+// caused them.
+func (m providerModel) seed() (string, path.Path, error) {
+	var seed string
+	switch {
+	case !m.Seed.IsNull() && !m.SeedFile.IsNull():
+		return "", path.Root("seed_file"), fmt.Errorf("seed_file conflicts with seed, set only one of them")
+	case !m.Seed.IsNull():
+		seed = m.Seed.ValueString()
+	case !m.SeedFile.IsNull():
+		data, err := os.ReadFile(m.SeedFile.ValueString())
+		if err != nil {
+			return "", path.Root("seed_file"), fmt.Errorf("reading seed_file: %w", err)
+		}
+		if seed = strings.TrimSpace(string(data)); seed == "" {
+			return "", path.Root("seed_file"), fmt.Errorf("seed_file %s is empty", m.SeedFile.ValueString())
+		}
+	default:
+		if seed = os.Getenv(seedEnv); seed == "" {
+			return "", path.Root("seed"), fmt.Errorf("seed is required: set seed, seed_file or the %s environment variable", seedEnv)
+		}
+	}
+	if seed == "" {
+		return "", path.Root("seed"), fmt.Errorf("seed must not be empty")
+	}
+	return seed, path.Empty(), nil
+}
+
+// scheduledSeeds converts the seeds blocks. Errors come with the attribute
+// that caused them.
+func (m providerModel) scheduledSeeds(blocks []seedModel) ([]timeslug.Seed, path.Path, error) {
+	if !m.Seed.IsNull() || !m.SeedFile.IsNull() {
+		return nil, path.Root("seeds"), fmt.Errorf("seeds conflicts with seed and seed_file, set only one of them")
+	}
+	seeds := make([]timeslug.Seed, len(blocks))
+	for i, b := range blocks {
+		seeds[i] = timeslug.Seed{ID: b.ID.ValueString(), Value: b.Seed.ValueString()}
+		for _, bound := range []struct {
+			attr  string
+			value types.String
+			t     *time.Time
+		}{
+			{"valid_from", b.ValidFrom, &seeds[i].ValidFrom},
+			{"valid_until", b.ValidUntil, &seeds[i].ValidUntil},
+		} {
+			if bound.value.IsNull() {
+				continue
+			}
+			t, err := timeslug.ParseTime(bound.value.ValueString())
+			if err != nil {
+				return nil, path.Root("seeds").AtListIndex(i).AtName(bound.attr), err
+			}
+			*bound.t = t
+		}
+	}
+	if _, err := timeslug.New("", timeslug.WithSeeds(seeds...)); err != nil {
+		return nil, path.Root("seeds"), err
+	}
+	return seeds, path.Empty(), nil
+}
+
+// hasUnknown reports whether the configuration is not known yet at plan
+// time.
+func (m providerModel) hasUnknown(blocks []seedModel) bool {
+	values := []attr.Value{m.Seed, m.SeedFile, m.Version, m.Length, m.Window, m.Interval, m.Mode, m.Timezone, m.Seeds}
+	for _, b := range blocks {
+		values = append(values, b.ID, b.Seed, b.ValidFrom, b.ValidUntil)
+	}
+	return slices.ContainsFunc(values, attr.Value.IsUnknown)
 }
 
 func New(version string) func() provider.Provider {
@@ -36,10 +247,72 @@ func (p *timeslugProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 		Description: "Generates deterministic time-rotating slugs from a seed.",
 		Attributes: map[string]schema.Attribute{
 			"seed": schema.StringAttribute{
-				Description: "Secret seed for slug generation.",
-				Required:    true,
+				Description: "Secret seed for slug generation. Conflicts with seed_file and seeds. Default: the TIMESLUG_SEED environment variable",
+				Optional:    true,
 				Sensitive:   true,
 			},
+			"seed_file": schema.StringAttribute{
+				Description: "Path of a file holding the secret seed; surrounding whitespace is trimmed. Conflicts with seed and seeds",
+				Optional:    true,
+			},
+			"algorithm_version": schema.Int64Attribute{
+				Description: "Default algorithm_version for data sources, resources and ephemeral resources. Default: 1",
+				Optional:    true,
+				Validators:  versionValidators,
+			},
+			"length": schema.Int64Attribute{
+				Description: "Default length for data sources, resources and ephemeral resources. Default: 3",
+				Optional:    true,
+				Validators:  lengthValidators,
+			},
+			"window": schema.Int64Attribute{
+				Description: "Default window for timeslug_slugs data sources. Default: 7",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"interval": schema.StringAttribute{
+				Description: "Default interval for data sources, resources and ephemeral resources. Default: day",
+				Optional:    true,
+				Validators:  intervalValidators,
+			},
+			"mode": schema.StringAttribute{
+				Description: "Default mode for data sources, resources and ephemeral resources. Default: bip39",
+				Optional:    true,
+				Validators:  modeValidators,
+			},
+			"timezone": schema.StringAttribute{
+				Description: "Default timezone for data sources, resources and ephemeral resources. Default: UTC",
+				Optional:    true,
+				Validators:  timezoneValidators,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"seeds": schema.ListNestedBlock{
+				Description: "Scheduled secret seeds replacing seed, for rotating the seed with overlap. Each period is derived with the seed valid when it begins that has the latest valid_from; slugs of every seed valid for a period verify. Together the seeds must cover all time. Conflicts with seed and seed_file",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique name of the seed, reported as seed_id",
+							Required:    true,
+						},
+						"seed": schema.StringAttribute{
+							Description: "Secret seed",
+							Required:    true,
+							Sensitive:   true,
+						},
+						"valid_from": schema.StringAttribute{
+							Description: "UTC time the seed is valid from (e.g., 2026-03-01). Default: valid since always",
+							Optional:    true,
+							Validators:  timeValidators,
+						},
+						"valid_until": schema.StringAttribute{
+							Description: "UTC time the seed is valid until, exclusive (e.g., 2026-03-08). Default: valid forever",
+							Optional:    true,
+							Validators:  timeValidators,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -50,14 +323,67 @@ func (p *timeslugProvider) Configure(ctx context.Context, req provider.Configure
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.DataSourceData = config.Seed.ValueString()
-	resp.ResourceData = config.Seed.ValueString()
+	var blocks []seedModel
+	if !config.Seeds.IsUnknown() {
+		resp.Diagnostics.Append(config.Seeds.ElementsAs(ctx, &blocks, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if config.hasUnknown(blocks) {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+		data := providerData{unknown: true}
+		resp.DataSourceData = data
+		resp.ResourceData = data
+		resp.EphemeralResourceData = data
+		return
+	}
+	data := providerData{
+		length:   int(config.Length.ValueInt64()),
+		window:   int(config.Window.ValueInt64()),
+		interval: config.Interval.ValueString(),
+		mode:     config.Mode.ValueString(),
+		timezone: config.Timezone.ValueString(),
+		cache:    &generatorCache{},
+	}
+	var attrPath path.Path
+	var err error
+	if len(blocks) > 0 {
+		data.seeds, attrPath, err = config.scheduledSeeds(blocks)
+	} else {
+		data.seed, attrPath, err = config.seed()
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attrPath, "Config Error", err.Error())
+		return
+	}
+	if !config.Version.IsNull() {
+		data.version = int(config.Version.ValueInt64())
+		if !slices.Contains(timeslug.Versions(), data.version) {
+			resp.Diagnostics.AddAttributeError(path.Root("algorithm_version"), "Config Error", fmt.Sprintf("%s: %d", timeslug.ErrInvalidVersion, data.version))
+			return
+		}
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 }
 
 func (p *timeslugProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{NewSlugsDataSource}
+	return []func() datasource.DataSource{NewSlugsDataSource, NewVerifyDataSource}
 }
 
 func (p *timeslugProvider) Resources(_ context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{NewRotatingSlugResource}
+}
+
+func (p *timeslugProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{NewSlugEphemeralResource}
+}
+
+func (p *timeslugProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{NewSlugFunction, NewCurrentFunction, NewWindowFunction, NewVerifyFunction}
 }
@@ -2,11 +2,23 @@ package provider
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
@@ -30,17 +42,363 @@ func TestProvider(t *testing.T) {
 	if schemaResp.Diagnostics.HasError() {
 		t.Fatal(schemaResp.Diagnostics)
 	}
-	if _, ok := schemaResp.Schema.Attributes["seed"]; !ok {
-		t.Error("missing seed attribute")
+	for _, attr := range []string{"seed", "seed_file", "algorithm_version", "length", "window", "interval", "mode", "timezone"} {
+		if _, ok := schemaResp.Schema.Attributes[attr]; !ok {
+			t.Errorf("missing %s attribute", attr)
+		}
+	}
+	if _, ok := schemaResp.Schema.Blocks["seeds"]; !ok {
+		t.Error("missing seeds block")
 	}
 
 	// DataSources
-	if ds := p.DataSources(ctx); len(ds) != 1 {
-		t.Errorf("expected 1 data source, got %d", len(ds))
+	if ds := p.DataSources(ctx); len(ds) != 2 {
+		t.Errorf("expected 2 data sources, got %d", len(ds))
+	}
+
+	// EphemeralResources
+	if er := p.(provider.ProviderWithEphemeralResources).EphemeralResources(ctx); len(er) != 1 {
+		t.Errorf("expected 1 ephemeral resource, got %d", len(er))
+	}
+
+	// Functions
+	if fn := p.(provider.ProviderWithFunctions).Functions(ctx); len(fn) != 4 {
+		t.Errorf("expected 4 functions, got %d", len(fn))
 	}
 
 	// Resources
-	if rs := p.Resources(ctx); len(rs) != 0 {
-		t.Errorf("expected 0 resources, got %d", len(rs))
+	if rs := p.Resources(ctx); len(rs) != 1 {
+		t.Errorf("expected 1 resource, got %d", len(rs))
+	}
+}
+
+// providerConfig returns a provider configuration with the given attribute
+// values; the others are null.
+func providerConfig(ctx context.Context, values map[string]tftypes.Value) tfsdk.Config {
+	schemaResp := &provider.SchemaResponse{}
+	New("test")().Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	raw := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		raw[name] = tftypes.NewValue(attrType, nil)
+		if v, ok := values[name]; ok {
+			raw[name] = v
+		}
+	}
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, raw)}
+}
+
+func TestProviderConfigureSeed(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	seedFile := filepath.Join(dir, "seed")
+	if err := os.WriteFile(seedFile, []byte("file-seed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	null := tftypes.NewValue(tftypes.String, nil)
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+
+	cases := []struct {
+		name           string
+		seed, file     tftypes.Value
+		env, want, err string
+	}{
+		{"seed", str("hcl-seed"), null, "env-seed", "hcl-seed", ""},
+		{"seed_file", null, str(seedFile), "env-seed", "file-seed", ""},
+		{"environment", null, null, "env-seed", "env-seed", ""},
+		{"none", null, null, "", "", "seed is required"},
+		{"both", str("hcl-seed"), str(seedFile), "", "", "seed_file conflicts with seed"},
+		{"empty seed", str(""), null, "env-seed", "", "seed must not be empty"},
+		{"empty file", null, str(emptyFile), "", "", "is empty"},
+		{"missing file", null, str(filepath.Join(dir, "missing")), "", "", "reading seed_file"},
+	}
+	for _, tc := range cases {
+		t.Setenv(seedEnv, tc.env)
+		resp := &provider.ConfigureResponse{}
+		New("test")().Configure(ctx, provider.ConfigureRequest{Config: providerConfig(ctx, map[string]tftypes.Value{"seed": tc.seed, "seed_file": tc.file})}, resp)
+		if tc.err != "" {
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tc.err) {
+				t.Errorf("%s: expected %q, got %v", tc.name, tc.err, resp.Diagnostics)
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", tc.name, resp.Diagnostics)
+		}
+		if data := resp.DataSourceData.(providerData); data.seed != tc.want {
+			t.Errorf("%s: got seed %q, want %q", tc.name, data.seed, tc.want)
+		}
+	}
+}
+
+func TestProviderConfigureDefaults(t *testing.T) {
+	ctx := context.Background()
+	config := providerConfig(ctx, map[string]tftypes.Value{
+		"seed":     tftypes.NewValue(tftypes.String, "seedphrase"),
+		"length":   tftypes.NewValue(tftypes.Number, 16),
+		"window":   tftypes.NewValue(tftypes.Number, 3),
+		"interval": tftypes.NewValue(tftypes.String, "hour"),
+		"mode":     tftypes.NewValue(tftypes.String, "obfuscated"),
+		"timezone": tftypes.NewValue(tftypes.String, "Europe/Berlin"),
+	})
+	resp := &provider.ConfigureResponse{}
+	New("test")().Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	data := resp.DataSourceData.(providerData)
+	if data.length != 16 || data.window != 3 || data.interval != "hour" || data.mode != "obfuscated" || data.timezone != "Europe/Berlin" {
+		t.Errorf("got %+v", data)
+	}
+	// Data sources, resources and ephemeral resources share the cache.
+	if data.cache == nil || data.cache != resp.ResourceData.(providerData).cache || data.cache != resp.EphemeralResourceData.(providerData).cache {
+		t.Error("expected a shared generator cache")
+	}
+}
+
+func TestProviderConfigureVersion(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	if attr := schemaResp.Schema.Attributes["algorithm_version"].(schema.Int64Attribute); len(attr.Validators) == 0 {
+		t.Error("algorithm_version has no validators")
+	}
+
+	config := providerConfig(ctx, map[string]tftypes.Value{
+		"seed":              tftypes.NewValue(tftypes.String, "seedphrase"),
+		"algorithm_version": tftypes.NewValue(tftypes.Number, 9),
+	})
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("got %v", resp.Diagnostics)
 	}
+	if d, ok := resp.Diagnostics[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(path.Root("algorithm_version")) {
+		t.Errorf("expected error on algorithm_version, got %v", resp.Diagnostics[0])
+	}
+}
+
+func TestProviderConfigureUnknown(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	config := providerConfig(ctx, map[string]tftypes.Value{"seed": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)})
+
+	// A client that supports deferral defers everything using the provider.
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config, ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: true}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+		t.Errorf("got deferred=%v", resp.Deferred)
+	}
+
+	// Otherwise data sources learn that the seed is unknown.
+	resp = &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if data, ok := resp.DataSourceData.(providerData); !ok || !data.unknown || resp.Deferred != nil {
+		t.Errorf("got data=%v deferred=%v", resp.DataSourceData, resp.Deferred)
+	}
+}
+
+func TestProviderScheduledSeeds(t *testing.T) {
+	str := types.StringValue
+	null := types.StringNull()
+	cases := []struct {
+		name   string
+		config providerModel
+		blocks []seedModel
+		err    string
+	}{
+		{"rotation", providerModel{Seed: null, SeedFile: null}, []seedModel{
+			{ID: str("old"), Seed: str("a"), ValidFrom: null, ValidUntil: str("2026-02-05")},
+			{ID: str("new"), Seed: str("b"), ValidFrom: str("2026-02-04"), ValidUntil: null},
+		}, ""},
+		{"conflict", providerModel{Seed: str("a"), SeedFile: null}, []seedModel{
+			{ID: str("only"), Seed: str("b"), ValidFrom: null, ValidUntil: null},
+		}, "seeds conflicts with seed"},
+		{"bad time", providerModel{Seed: null, SeedFile: null}, []seedModel{
+			{ID: str("only"), Seed: str("b"), ValidFrom: str("soon"), ValidUntil: null},
+		}, "invalid time"},
+		{"gap", providerModel{Seed: null, SeedFile: null}, []seedModel{
+			{ID: str("old"), Seed: str("a"), ValidFrom: null, ValidUntil: str("2026-02-04")},
+			{ID: str("new"), Seed: str("b"), ValidFrom: str("2026-02-05"), ValidUntil: null},
+		}, "no seed from"},
+	}
+	for _, tc := range cases {
+		seeds, _, err := tc.config.scheduledSeeds(tc.blocks)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%s: expected %q, got %v", tc.name, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if len(seeds) != 2 || seeds[0].ID != "old" || !seeds[1].ValidFrom.Equal(time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("%s: got %+v", tc.name, seeds)
+		}
+	}
+}
+
+// Acceptance tests
+func TestAccProvider_defaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" {
+  seed   = "seedphrase"
+  mode   = "obfuscated"
+  length = 16
+  window = 3
+}
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-04"
+}
+data "timeslug_slugs" "override" {
+  anchor = "2026-02-03"
+  mode   = "bip39"
+  length = 3
+  window = 1
+}
+resource "timeslug_rotating_slug" "test" {}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.#", "3"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "trybeambold8"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.slug", "brightbeamvivar"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.override", "slugs.0.slug", "exoticangryanswer"),
+				resource.TestCheckResourceAttr("timeslug_rotating_slug.test", "mode", "obfuscated"),
+				resource.TestCheckResourceAttr("timeslug_rotating_slug.test", "length", "16"),
+				resource.TestCheckResourceAttr("timeslug_rotating_slug.test", "interval", "day"),
+			),
+		}},
+	})
+}
+
+func TestAccProvider_seedSources(t *testing.T) {
+	seedFile := filepath.Join(t.TempDir(), "seed")
+	if err := os.WriteFile(seedFile, []byte("seedphrase\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(seedEnv, "seedphrase")
+	check := resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "exoticangryanswer")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" {}
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+  window = 1
+}`,
+			Check: check,
+		}, {
+			Config: `
+provider "timeslug" { seed_file = "` + seedFile + `" }
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+  window = 1
+}`,
+			Check: check,
+		}, {
+			Config: `
+provider "timeslug" {
+  seed      = "seedphrase"
+  seed_file = "` + seedFile + `"
+}
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-03"
+}`,
+			ExpectError: regexp.MustCompile("seed_file conflicts with seed"),
+		}},
+	})
+}
+
+func TestAccProvider_seeds(t *testing.T) {
+	seeds := `
+provider "timeslug" {
+  seeds {
+    id          = "old"
+    seed        = "seedphrase"
+    valid_until = "2026-02-05"
+  }
+  seeds {
+    id         = "new"
+    seed       = "rotated"
+    valid_from = "2026-02-04"
+  }
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: seeds + `
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-04"
+  window = 3
+}
+data "timeslug_verify" "old" {
+  slug      = "policekitchencomic"
+  anchor    = "2026-02-04"
+  tolerance = 0
+}
+data "timeslug_verify" "expired" {
+  slug      = "orderepisodeuniform"
+  anchor    = "2026-02-05"
+  tolerance = 0
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.slug", "exoticangryanswer"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.0.seed_id", "old"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.slug", "shoulderartefactmerit"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.1.seed_id", "new"),
+				resource.TestCheckResourceAttr("data.timeslug_slugs.test", "slugs.2.seed_id", "new"),
+				resource.TestCheckResourceAttr("data.timeslug_verify.old", "valid", "true"),
+				resource.TestCheckResourceAttr("data.timeslug_verify.old", "seed_id", "old"),
+				resource.TestCheckResourceAttr("data.timeslug_verify.expired", "valid", "false"),
+				resource.TestCheckNoResourceAttr("data.timeslug_verify.expired", "seed_id"),
+			),
+		}, {
+			Config: `
+provider "timeslug" {
+  seeds {
+    id          = "old"
+    seed        = "seedphrase"
+    valid_until = "2026-02-04"
+  }
+  seeds {
+    id         = "new"
+    seed       = "rotated"
+    valid_from = "2026-02-05"
+  }
+}
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-04"
+}`,
+			ExpectError: regexp.MustCompile("no seed from 2026-02-04T00:00:00Z to 2026-02-05T00:00:00Z"),
+		}, {
+			Config: `
+provider "timeslug" {
+  seed = "seedphrase"
+  seeds {
+    id   = "only"
+    seed = "rotated"
+  }
+}
+data "timeslug_slugs" "test" {
+  anchor = "2026-02-04"
+}`,
+			ExpectError: regexp.MustCompile("seeds conflicts with seed"),
+		}},
+	})
 }
@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &rotatingSlugResource{}
+	_ resource.ResourceWithConfigure      = &rotatingSlugResource{}
+	_ resource.ResourceWithValidateConfig = &rotatingSlugResource{}
+	_ resource.ResourceWithModifyPlan     = &rotatingSlugResource{}
+)
+
+// now is the wall clock used to decide rotation, replaceable in tests.
+var now = time.Now
+
+type rotatingSlugResource struct {
+	providerData
+}
+
+type rotatingSlugModel struct {
+	generatorModel
+	ID       types.String `tfsdk:"id"`
+	Slug     types.String `tfsdk:"slug"`
+	Period   types.String `tfsdk:"period"`
+	Hash     types.String `tfsdk:"hash"`
+	Rotation types.String `tfsdk:"rotation_rfc3339"`
+	SeedID   types.String `tfsdk:"seed_id"`
+}
+
+func NewRotatingSlugResource() resource.Resource {
+	return &rotatingSlugResource{}
+}
+
+func (r *rotatingSlugResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rotating_slug"
+}
+
+func (r *rotatingSlugResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description:   description,
+			Computed:      true,
+			PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		}
+	}
+	resp.Schema = schema.Schema{
+		Description: "Pins the slug for the current period in state and replaces it once the period expires.",
+		Attributes: map[string]schema.Attribute{
+			"length": schema.Int64Attribute{
+				Description:   lengthDescription,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+				Validators:    lengthValidators,
+			},
+			"interval": schema.StringAttribute{
+				Description:   intervalDescription,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    intervalValidators,
+			},
+			"epoch": schema.StringAttribute{
+				Description:   epochDescription,
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    timeValidators,
+			},
+			"iso_week": schema.BoolAttribute{
+				Description:   isoWeekDescription,
+				Optional:      true,
+				Computed:      true,
+				Default:       booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"timezone": schema.StringAttribute{
+				Description:   timezoneDescription,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    timezoneValidators,
+			},
+			"mode": schema.StringAttribute{
+				Description:   modeDescription,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    modeValidators,
+			},
+			"language": schema.StringAttribute{
+				Description:   languageDescription,
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    languageValidators,
+			},
+			"separator": schema.StringAttribute{
+				Description:   separatorDescription,
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"case": schema.StringAttribute{
+				Description:   caseDescription,
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    caseValidators,
+			},
+			"wordlist": schema.ListAttribute{
+				Description:   wordlistDescription,
+				ElementType:   types.StringType,
+				Optional:      true,
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+			},
+			"wordlist_file": schema.StringAttribute{
+				Description:   wordlistFileDescription,
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"namespace": schema.StringAttribute{
+				Description:   namespaceDescription,
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"algorithm_version": schema.Int64Attribute{
+				Description:   versionDescription,
+				Optional:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+				Validators:    versionValidators,
+			},
+			"id":               computed("Period the slug was generated for."),
+			"slug":             computed("Slug for the period that was current at creation."),
+			"period":           computed("Time period the slug is valid for."),
+			"hash":             computed("Verification hash for the slug."),
+			"rotation_rfc3339": computed("Time the period ends and the resource is planned for replacement."),
+			"seed_id":          computed("id of the provider seeds block the slug was derived with, null without seeds blocks."),
+		},
+	}
+}
+
+func (r *rotatingSlugResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Config Error", fmt.Sprintf("expected providerData, got %T", req.ProviderData))
+		return
+	}
+	r.providerData = data
+}
+
+func (r *rotatingSlugResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data rotatingSlugModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.validate(&resp.Diagnostics)
+}
+
+// ModifyPlan fills length, interval, mode and timezone from the provider
+// defaults when the configuration leaves them unset, and replaces the
+// resource when a changed provider default changes them.
+func (r *rotatingSlugResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.unknown {
+		return
+	}
+	var config, plan rotatingSlugModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Length.IsNull() {
+		plan.Length = types.Int64Value(int64(r.defaultLength()))
+	}
+	if config.Interval.IsNull() {
+		plan.Interval = types.StringValue(r.defaultInterval())
+	}
+	if config.Mode.IsNull() {
+		plan.Mode = types.StringValue(r.defaultMode())
+	}
+	if config.Timezone.IsNull() {
+		// Null rather than UTC, so resources created before the provider
+		// had a timezone keep their state.
+		plan.Timezone = types.StringNull()
+		if tz := r.defaultTimezone(); tz != "" {
+			plan.Timezone = types.StringValue(tz)
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state rotatingSlugModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, c := range []struct {
+			attr        string
+			plan, state attr.Value
+		}{
+			{"length", plan.Length, state.Length},
+			{"interval", plan.Interval, state.Interval},
+			{"mode", plan.Mode, state.Mode},
+			{"timezone", plan.Timezone, state.Timezone},
+		} {
+			if !c.plan.Equal(c.state) {
+				resp.RequiresReplace.Append(path.Root(c.attr))
+			}
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *rotatingSlugResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data rotatingSlugModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	g, err := data.generator(r.providerData)
+	if err != nil {
+		// ModifyPlan fills in the provider defaults: the configuration
+		// tells which attributes were set.
+		var config rotatingSlugModel
+		resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+		config.addError(&resp.Diagnostics, "Generation Failed", err)
+		return
+	}
+	// At derives with the seed active now, not a seed scheduled later.
+	at := now()
+	period, rotation := g.Bounds(at)
+	slug := g.At(at)
+
+	data.ID = types.StringValue(period)
+	data.Slug = types.StringValue(slug.Value)
+	data.Period = types.StringValue(slug.Period)
+	data.Hash = types.StringValue(slug.Hash)
+	data.Rotation = types.StringValue(rotation.Format(time.RFC3339))
+	data.SeedID = seedIDValue(slug.SeedID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *rotatingSlugResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data rotatingSlugModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Dropping the resource from state once its period has passed makes
+	// Terraform plan a fresh create for the new period.
+	rotation, err := time.Parse(time.RFC3339, data.Rotation.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid State", fmt.Sprintf("rotation_rfc3339: %s", err))
+		return
+	}
+	if !now().Before(rotation) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called with changes: every configurable attribute
+// requires replacement.
+func (r *rotatingSlugResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data rotatingSlugModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *rotatingSlugResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
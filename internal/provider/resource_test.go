@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
+)
+
+func TestRotatingSlugResource(t *testing.T) {
+	ctx := context.Background()
+	r := NewRotatingSlugResource()
+
+	// Metadata
+	metaResp := &fwresource.MetadataResponse{}
+	r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "timeslug"}, metaResp)
+	if metaResp.TypeName != "timeslug_rotating_slug" {
+		t.Errorf("got type=%q", metaResp.TypeName)
+	}
+
+	// Schema
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatal(schemaResp.Diagnostics)
+	}
+	optional := []string{"length", "interval", "iso_week", "epoch", "timezone", "mode", "language", "separator", "case", "wordlist", "wordlist_file", "algorithm_version"}
+	computed := []string{"id", "slug", "period", "hash", "rotation_rfc3339", "seed_id"}
+	for _, attr := range slices.Concat(optional, computed) {
+		if _, ok := schemaResp.Schema.Attributes[attr]; !ok {
+			t.Errorf("missing attribute %q", attr)
+		}
+	}
+
+	// Configure
+	concrete := r.(*rotatingSlugResource)
+	configResp := &fwresource.ConfigureResponse{}
+	concrete.Configure(ctx, fwresource.ConfigureRequest{ProviderData: providerData{seed: "test-seed"}}, configResp)
+	if configResp.Diagnostics.HasError() {
+		t.Fatal(configResp.Diagnostics)
+	}
+	concrete.Configure(ctx, fwresource.ConfigureRequest{ProviderData: 123}, configResp)
+	if !configResp.Diagnostics.HasError() {
+		t.Error("expected error for wrong type")
+	}
+
+	// Read drops the resource once the period has passed
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, &rotatingSlugModel{
+		generatorModel: generatorModel{
+			Length:   types.Int64Value(3),
+			Interval: types.StringValue("day"),
+			Mode:     types.StringValue("bip39"),
+			Wordlist: types.ListNull(types.StringType),
+		},
+		ID:       types.StringValue("2026-02-03"),
+		Slug:     types.StringValue("exoticangryanswer"),
+		Period:   types.StringValue("2026-02-03"),
+		Hash:     types.StringValue("50011c26d0"),
+		Rotation: types.StringValue("2026-02-04T00:00:00Z"),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	t.Cleanup(func() { now = time.Now })
+	for at, removed := range map[string]bool{
+		"2026-02-03T23:59:59Z": false,
+		"2026-02-04T00:00:00Z": true,
+	} {
+		now = func() time.Time { ts, _ := time.Parse(time.RFC3339, at); return ts }
+		readResp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatal(readResp.Diagnostics)
+		}
+		if got := readResp.State.Raw.IsNull(); got != removed {
+			t.Errorf("%s: removed=%v, want %v", at, got, removed)
+		}
+	}
+}
+
+func TestRotatingSlugResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	// The next seed is scheduled after now: the slug uses the active one.
+	r := &rotatingSlugResource{providerData: providerData{seeds: []timeslug.Seed{
+		{ID: "2025", Value: "seedphrase"},
+		{ID: "2026", Value: "nextphrase", ValidFrom: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := plan.Set(ctx, &rotatingSlugModel{generatorModel: generatorModel{
+		Length:   types.Int64Value(3),
+		Interval: types.StringValue("day"),
+		Mode:     types.StringValue("bip39"),
+		Wordlist: types.ListNull(types.StringType),
+	}})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	t.Cleanup(func() { now = time.Now })
+	now = func() time.Time { return time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC) }
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var data rotatingSlugModel
+	resp.State.Get(ctx, &data)
+	if data.Slug.ValueString() != "exoticangryanswer" || data.Hash.ValueString() != "50011c26d0" || data.SeedID.ValueString() != "2025" {
+		t.Errorf("got %s/%s/%s", data.Slug, data.Hash, data.SeedID)
+	}
+	if data.ID.ValueString() != "2026-02-03" || data.Rotation.ValueString() != "2026-02-04T00:00:00Z" {
+		t.Errorf("got %s/%s", data.ID, data.Rotation)
+	}
+}
+
+func TestRotatingSlugResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := &rotatingSlugResource{providerData: providerData{seed: "seedphrase", length: 16, mode: "obfuscated"}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	value := func(m generatorModel) tftypes.Value {
+		t.Helper()
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		m.Wordlist = types.ListNull(types.StringType)
+		if diags := state.Set(ctx, &rotatingSlugModel{generatorModel: m}); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return state.Raw
+	}
+	null := value(generatorModel{})
+	stored := value(generatorModel{Length: types.Int64Value(3), Interval: types.StringValue("day"), Mode: types.StringValue("bip39")})
+	override := value(generatorModel{Mode: types.StringValue("bip39")})
+
+	cases := []struct {
+		name           string
+		config, state  tftypes.Value
+		length         int64
+		mode           string
+		requireReplace bool
+	}{
+		{"create", null, tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil), 16, "obfuscated", false},
+		{"provider default changed", null, stored, 16, "obfuscated", true},
+		{"config overrides", override, stored, 16, "bip39", true},
+	}
+	for _, tc := range cases {
+		req := fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tc.config},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.config},
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", tc.name, resp.Diagnostics)
+		}
+		var plan rotatingSlugModel
+		resp.Plan.Get(ctx, &plan)
+		if plan.Length.ValueInt64() != tc.length || plan.Mode.ValueString() != tc.mode || plan.Interval.ValueString() != "day" {
+			t.Errorf("%s: planned %s/%s/%s", tc.name, plan.Length, plan.Interval, plan.Mode)
+		}
+		if got := len(resp.RequiresReplace) > 0; got != tc.requireReplace {
+			t.Errorf("%s: requires replace %v, want %v", tc.name, resp.RequiresReplace, tc.requireReplace)
+		}
+	}
+
+	// Unchanged defaults keep the resource.
+	r.providerData = providerData{seed: "seedphrase"}
+	req := fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: null},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: stored},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: stored},
+	}
+	resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() || len(resp.RequiresReplace) > 0 {
+		t.Errorf("got %v, requires replace %v", resp.Diagnostics, resp.RequiresReplace)
+	}
+}
+
+// Acceptance tests
+func TestAccRotatingSlugResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+resource "timeslug_rotating_slug" "test" {
+  interval = "hour"
+  mode     = "obfuscated"
+  length   = 16
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttrSet("timeslug_rotating_slug.test", "slug"),
+				resource.TestMatchResourceAttr("timeslug_rotating_slug.test", "period", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}$`)),
+				resource.TestMatchResourceAttr("timeslug_rotating_slug.test", "hash", regexp.MustCompile(`^[0-9a-f]{16}$`)),
+				resource.TestCheckResourceAttrSet("timeslug_rotating_slug.test", "rotation_rfc3339"),
+			),
+		}},
+	})
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
+)
+
+var _ validator.String = stringCheck{}
+
+// Validators of the attributes shared by every schema that derives slugs.
+var (
+	lengthValidators   = []validator.Int64{int64validator.Between(1, max(timeslug.MaxWords, timeslug.MaxCharacters))}
+	intervalValidators = []validator.String{stringCheck{
+		description: "value must be a rotation interval such as day or 15m",
+		check: func(s string) error {
+			_, err := timeslug.New("", timeslug.WithInterval(s))
+			return err
+		},
+	}}
+	timeValidators = []validator.String{stringCheck{
+		description: "value must be a time such as 2006-01-02 or 2006-01-02T15:04:05",
+		check: func(s string) error {
+			_, err := timeslug.ParseTime(s)
+			return err
+		},
+	}}
+	timezoneValidators = []validator.String{stringCheck{
+		description: "value must be an IANA time zone such as America/New_York",
+		check: func(s string) error {
+			_, err := timeslug.LoadLocation(s)
+			return err
+		},
+	}}
+	modeValidators     = []validator.String{stringvalidator.OneOfCaseInsensitive(timeslug.ModeBIP39, timeslug.ModeObfuscated)}
+	languageValidators = []validator.String{stringvalidator.OneOfCaseInsensitive(timeslug.Languages()...)}
+	caseValidators     = []validator.String{stringvalidator.OneOfCaseInsensitive(timeslug.CaseLower, timeslug.CaseTitle, timeslug.CaseCamel, timeslug.CaseUpper)}
+	versionValidators  = []validator.Int64{int64validator.OneOf(versions()...)}
+)
+
+// stringCheck validates a string with a check function of the timeslug
+// package, so the schema accepts exactly what the generator accepts.
+type stringCheck struct {
+	description string
+	check       func(string) error
+}
+
+func (v stringCheck) Description(_ context.Context) string {
+	return v.description
+}
+
+func (v stringCheck) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringCheck) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := v.check(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Config Error", fmt.Sprintf("%s: %s", v.description, err))
+	}
+}
+
+// versions returns the algorithm versions as int64 values.
+func versions() []int64 {
+	var values []int64
+	for _, v := range timeslug.Versions() {
+		values = append(values, int64(v))
+	}
+	return values
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringValidators(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name  string
+		v     validator.String
+		value types.String
+		valid bool
+	}{
+		{"mode", modeValidators[0], types.StringValue("bip39"), true},
+		{"mode ignores case", modeValidators[0], types.StringValue("Obfuscated"), true},
+		{"mode", modeValidators[0], types.StringValue("words"), false},
+		{"case", caseValidators[0], types.StringValue("camel"), true},
+		{"case", caseValidators[0], types.StringValue("snake"), false},
+		{"language", languageValidators[0], types.StringValue("english"), true},
+		{"language", languageValidators[0], types.StringValue("klingon"), false},
+		{"interval", intervalValidators[0], types.StringValue("15m"), true},
+		{"interval", intervalValidators[0], types.StringValue("fortnight"), false},
+		{"time", timeValidators[0], types.StringValue("2026-02-03T15:04"), true},
+		{"time", timeValidators[0], types.StringValue("yesterday"), false},
+		{"timezone", timezoneValidators[0], types.StringValue("America/New_York"), true},
+		{"timezone", timezoneValidators[0], types.StringValue("Nowhere/Special"), false},
+		{"null", modeValidators[0], types.StringNull(), true},
+		{"unknown", modeValidators[0], types.StringUnknown(), true},
+	}
+	for _, tc := range cases {
+		resp := &validator.StringResponse{}
+		tc.v.ValidateString(ctx, validator.StringRequest{Path: path.Root("attr"), ConfigValue: tc.value}, resp)
+		if got := !resp.Diagnostics.HasError(); got != tc.valid {
+			t.Errorf("%s %s: valid=%v, want %v (%v)", tc.name, tc.value, got, tc.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestInt64Validators(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name  string
+		v     validator.Int64
+		value types.Int64
+		valid bool
+	}{
+		{"length", lengthValidators[0], types.Int64Value(1), true},
+		{"length", lengthValidators[0], types.Int64Value(0), false},
+		{"length", lengthValidators[0], types.Int64Value(1024), true},
+		{"length", lengthValidators[0], types.Int64Value(1025), false},
+		{"version", versionValidators[0], types.Int64Value(2), true},
+		{"version", versionValidators[0], types.Int64Value(99), false},
+		{"null", lengthValidators[0], types.Int64Null(), true},
+		{"unknown", lengthValidators[0], types.Int64Unknown(), true},
+	}
+	for _, tc := range cases {
+		resp := &validator.Int64Response{}
+		tc.v.ValidateInt64(ctx, validator.Int64Request{Path: path.Root("attr"), ConfigValue: tc.value}, resp)
+		if got := !resp.Diagnostics.HasError(); got != tc.valid {
+			t.Errorf("%s %s: valid=%v, want %v (%v)", tc.name, tc.value, got, tc.valid, resp.Diagnostics)
+		}
+	}
+}
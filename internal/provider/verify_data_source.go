@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sensiblebit/terraform-provider-timeslug/timeslug"
+)
+
+var (
+	_ datasource.DataSource                   = &verifyDataSource{}
+	_ datasource.DataSourceWithConfigure      = &verifyDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &verifyDataSource{}
+)
+
+type verifyDataSource struct {
+	providerData
+}
+
+type verifyModel struct {
+	generatorModel
+	Slug      types.String `tfsdk:"slug"`
+	Anchor    types.String `tfsdk:"anchor"`
+	Tolerance types.Int64  `tfsdk:"tolerance"`
+	ID        types.String `tfsdk:"id"`
+	Valid     types.Bool   `tfsdk:"valid"`
+	Period    types.String `tfsdk:"period"`
+	Offset    types.Int64  `tfsdk:"offset"`
+	SeedID    types.String `tfsdk:"seed_id"`
+}
+
+func NewVerifyDataSource() datasource.DataSource {
+	return &verifyDataSource{}
+}
+
+func (d *verifyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_verify"
+}
+
+func (d *verifyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether a slug belongs to the period containing anchor or to one of its neighbours.",
+		Attributes: map[string]schema.Attribute{
+			"slug": schema.StringAttribute{
+				Description: "Slug to verify.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"anchor": schema.StringAttribute{
+				Description: "Time to verify at (e.g., 2006-01-02 or 2006-01-02T15:04:05).",
+				Required:    true,
+				Validators:  timeValidators,
+			},
+			"tolerance": schema.Int64Attribute{
+				Description: "Number of periods before and after anchor that are also accepted. Default: 1",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"length": schema.Int64Attribute{
+				Description: lengthDescription,
+				Optional:    true,
+				Validators:  lengthValidators,
+			},
+			"interval": schema.StringAttribute{
+				Description: intervalDescription,
+				Optional:    true,
+				Validators:  intervalValidators,
+			},
+			"epoch": schema.StringAttribute{
+				Description: epochDescription,
+				Optional:    true,
+				Validators:  timeValidators,
+			},
+			"iso_week": schema.BoolAttribute{
+				Description: isoWeekDescription,
+				Optional:    true,
+			},
+			"timezone": schema.StringAttribute{
+				Description: timezoneDescription,
+				Optional:    true,
+				Validators:  timezoneValidators,
+			},
+			"mode": schema.StringAttribute{
+				Description: modeDescription,
+				Optional:    true,
+				Validators:  modeValidators,
+			},
+			"language": schema.StringAttribute{
+				Description: languageDescription,
+				Optional:    true,
+				Validators:  languageValidators,
+			},
+			"separator": schema.StringAttribute{
+				Description: separatorDescription,
+				Optional:    true,
+			},
+			"case": schema.StringAttribute{
+				Description: caseDescription,
+				Optional:    true,
+				Validators:  caseValidators,
+			},
+			"wordlist": schema.ListAttribute{
+				Description: wordlistDescription,
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"wordlist_file": schema.StringAttribute{
+				Description: wordlistFileDescription,
+				Optional:    true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: namespaceDescription,
+				Optional:    true,
+			},
+			"algorithm_version": schema.Int64Attribute{
+				Description: versionDescription,
+				Optional:    true,
+				Validators:  versionValidators,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"valid": schema.BoolAttribute{
+				Description: "Whether the slug matched a period within tolerance.",
+				Computed:    true,
+			},
+			"period": schema.StringAttribute{
+				Description: "Period the slug belongs to, null if it is not valid.",
+				Computed:    true,
+			},
+			"offset": schema.Int64Attribute{
+				Description: "Position of period relative to anchor (-1 previous, 0 current, 1 next), null if the slug is not valid.",
+				Computed:    true,
+			},
+			"seed_id": schema.StringAttribute{
+				Description: "id of the provider seeds block the slug was derived with, null if the slug is not valid or without seeds blocks.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *verifyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Config Error", fmt.Sprintf("expected providerData, got %T", req.ProviderData))
+		return
+	}
+	d.providerData = data
+}
+
+func (d *verifyDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data verifyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.validate(&resp.Diagnostics)
+}
+
+func (d *verifyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data verifyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if unknownRead(d.providerData, data.unknownAttribute(map[string]attr.Value{"slug": data.Slug, "anchor": data.Anchor, "tolerance": data.Tolerance}), req, resp) {
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ID = types.StringUnknown()
+		data.Valid = types.BoolUnknown()
+		data.Period = types.StringUnknown()
+		data.Offset = types.Int64Unknown()
+		data.SeedID = types.StringUnknown()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	tolerance := int64(1)
+	if !data.Tolerance.IsNull() {
+		tolerance = data.Tolerance.ValueInt64()
+	}
+
+	g, err := data.generator(d.providerData)
+	if err != nil {
+		data.addError(&resp.Diagnostics, "Verification Failed", err)
+		return
+	}
+	anchor, err := g.ParseTime(data.Anchor.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("anchor"), "Verification Failed", err.Error())
+		return
+	}
+	match, err := g.Verify(data.Slug.ValueString(), anchor, int(tolerance))
+	if err != nil && !errors.Is(err, timeslug.ErrNoMatch) {
+		data.addError(&resp.Diagnostics, "Verification Failed", err)
+		return
+	}
+
+	data.Valid = types.BoolValue(err == nil)
+	data.Period = types.StringNull()
+	data.Offset = types.Int64Null()
+	data.SeedID = types.StringNull()
+	if err == nil {
+		data.Period = types.StringValue(match.Period)
+		data.Offset = types.Int64Value(int64(match.Offset))
+		data.SeedID = seedIDValue(match.SeedID)
+	}
+	data.ID = types.StringValue(data.id(d.providerData, g, data.Anchor.ValueString(), tolerance))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestVerifyDataSource(t *testing.T) {
+	ctx := context.Background()
+	ds := NewVerifyDataSource()
+
+	// Metadata
+	metaResp := &datasource.MetadataResponse{}
+	ds.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "timeslug"}, metaResp)
+	if metaResp.TypeName != "timeslug_verify" {
+		t.Errorf("got type=%q", metaResp.TypeName)
+	}
+
+	// Schema
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatal(schemaResp.Diagnostics)
+	}
+	required := []string{"slug", "anchor"}
+	optional := []string{"tolerance", "length", "interval", "iso_week", "epoch", "timezone", "mode", "language", "separator", "case", "wordlist", "wordlist_file", "algorithm_version"}
+	computed := []string{"id", "valid", "period", "offset"}
+	for _, attr := range slices.Concat(required, optional, computed) {
+		if _, ok := schemaResp.Schema.Attributes[attr]; !ok {
+			t.Errorf("missing attribute %q", attr)
+		}
+	}
+
+	// Configure
+	concrete := ds.(*verifyDataSource)
+	configResp := &datasource.ConfigureResponse{}
+	concrete.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData{seed: "test-seed"}}, configResp)
+	if configResp.Diagnostics.HasError() {
+		t.Fatal(configResp.Diagnostics)
+	}
+	concrete.Configure(ctx, datasource.ConfigureRequest{ProviderData: 123}, configResp)
+	if !configResp.Diagnostics.HasError() {
+		t.Error("expected error for wrong type")
+	}
+}
+
+// Acceptance tests
+func TestAccVerifyDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+provider "timeslug" { seed = "seedphrase" }
+data "timeslug_verify" "valid" {
+  slug   = "trybeambold8"
+  anchor = "2026-02-04T12:00:00"
+  length = 16
+  mode   = "obfuscated"
+}
+data "timeslug_verify" "expired" {
+  slug      = "trybeambold8"
+  anchor    = "2026-02-04T12:00:00"
+  tolerance = 0
+  length    = 16
+  mode      = "obfuscated"
+}`,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.timeslug_verify.valid", "valid", "true"),
+				resource.TestCheckResourceAttr("data.timeslug_verify.valid", "period", "2026-02-03"),
+				resource.TestCheckResourceAttr("data.timeslug_verify.valid", "offset", "-1"),
+				resource.TestCheckResourceAttr("data.timeslug_verify.expired", "valid", "false"),
+				resource.TestCheckNoResourceAttr("data.timeslug_verify.expired", "period"),
+			),
+		}},
+	})
+}
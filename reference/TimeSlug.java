@@ -3,6 +3,9 @@ import javax.crypto.spec.SecretKeySpec;
 import java.nio.file.Files;
 import java.nio.file.Path;
 import java.security.MessageDigest;
+import java.time.Instant;
+import java.time.ZoneOffset;
+import java.time.format.DateTimeFormatter;
 import java.util.*;
 
 /**
@@ -39,15 +42,19 @@ public class TimeSlug {
 
     public static void main(String[] args) throws Exception {
         // Load BIP39 wordlist
-        String bip39Path = "../internal/provider/bip39_english.txt";
+        String bip39Path = "../timeslug/bip39_english.txt";
         bip39Words = Files.readAllLines(Path.of(bip39Path));
 
         String seed = args.length > 0 ? args[0] : "seedphrase";
         String period = args.length > 1 ? args[1] : "2026-02-03";
         String mode = args.length > 2 ? args[2] : "obfuscated";
         int length = args.length > 3 ? Integer.parseInt(args[3]) : 16;
+        int version = args.length > 4 ? Integer.parseInt(args[4]) : 1;
+        String separator = args.length > 5 ? args[5] : "";
+        String wordCase = args.length > 6 ? args[6] : "lower";
+        String namespace = args.length > 7 ? args[7] : "";
 
-        String[] result = derive(seed, period, length, mode);
+        String[] result = derive(seed, period, length, mode, version, separator, wordCase, namespace);
         System.out.println("Mode:   " + mode);
         System.out.println("Period: " + period);
         System.out.println("Slug:   " + result[0]);
@@ -88,8 +95,7 @@ public class TimeSlug {
         return false;
     }
 
-    static String buildSynth(byte[] entropy) {
-        final int MIN_LEN = 10, MAX_LEN = 18;
+    static String synthCore(byte[] entropy) {
         offset = 0;
         StringBuilder result = new StringBuilder();
 
@@ -141,18 +147,10 @@ public class TimeSlug {
         } else {
             result.append(pick(entropy, SUFFIXES));
         }
+        return result.toString();
+    }
 
-        String slug = result.toString();
-
-        // Pad
-        int po = 20;
-        while (slug.length() < MIN_LEN) {
-            offset = po;
-            slug += syllable(entropy);
-            po = offset;
-        }
-
-        // Fix blocked
+    static String removeBlocked(String slug, byte[] entropy) {
         for (int i = 0; hasBlocked(slug) && i < 10; i++) {
             for (String b : BLOCKED) {
                 int idx = slug.toLowerCase().indexOf(b);
@@ -164,6 +162,34 @@ public class TimeSlug {
                 }
             }
         }
+        return slug;
+    }
+
+    static String removeTriples(String slug) {
+        StringBuilder cleaned = new StringBuilder();
+        for (int i = 0; i < slug.length(); i++) {
+            char c = slug.charAt(i);
+            if (i < 2 || !(c == slug.charAt(i - 1) && c == slug.charAt(i - 2))) {
+                cleaned.append(c);
+            }
+        }
+        return cleaned.toString();
+    }
+
+    /** Version 1: 10 to 18 characters regardless of length. */
+    static String buildSynth(byte[] entropy) {
+        final int MIN_LEN = 10, MAX_LEN = 18;
+        String slug = synthCore(entropy);
+
+        // Pad
+        int po = 20;
+        while (slug.length() < MIN_LEN) {
+            offset = po;
+            slug += syllable(entropy);
+            po = offset;
+        }
+
+        slug = removeBlocked(slug, entropy);
 
         // Truncate
         if (slug.length() > MAX_LEN) {
@@ -176,16 +202,82 @@ public class TimeSlug {
             if (slug.length() > MAX_LEN) slug = slug.substring(0, MAX_LEN);
         }
 
-        // Remove triple letters
-        StringBuilder cleaned = new StringBuilder();
-        for (int i = 0; i < slug.length(); i++) {
-            char c = slug.charAt(i);
-            if (i < 2 || !(c == slug.charAt(i - 1) && c == slug.charAt(i - 2))) {
-                cleaned.append(c);
+        return removeTriples(slug);
+    }
+
+    /** Block n of the entropy chain: HMAC(seed, block n-1 + seed:period + byte(n)). */
+    static byte[] chainBlock(String seed, String period, byte[] prev, int n) throws Exception {
+        Mac mac = Mac.getInstance("HmacSHA256");
+        mac.init(new SecretKeySpec(seed.getBytes(), "HmacSHA256"));
+        mac.update(prev);
+        mac.update((seed + ":" + period).getBytes());
+        mac.update((byte) n);
+        return mac.doFinal();
+    }
+
+    /** Computes block n of a chain from block n-1. */
+    interface NextBlock {
+        byte[] next(byte[] prev, int n) throws Exception;
+    }
+
+    static final String HKDF_SALT = "timeslug/v3";
+
+    /** HKDF-Extract (RFC 5869) of the seed with the version 3 salt. */
+    static byte[] hkdfExtract(String seed) throws Exception {
+        return hmacHash(HKDF_SALT, seed);
+    }
+
+    /** Version 3 HKDF info: salt, purpose, mode, namespace and period joined by NUL. */
+    static byte[] hkdfInfo(String purpose, String mode, String namespace, String period) {
+        return String.join("\0", HKDF_SALT, purpose, mode, namespace, period).getBytes();
+    }
+
+    /** Block n (from 0) of HKDF-Expand: HMAC(prk, block n-1 + info + byte(n+1)). */
+    static byte[] hkdfBlock(byte[] prk, byte[] info, byte[] prev, int n) throws Exception {
+        Mac mac = Mac.getInstance("HmacSHA256");
+        mac.init(new SecretKeySpec(prk, "HmacSHA256"));
+        mac.update(prev);
+        mac.update(info);
+        mac.update((byte) (n + 1));
+        return mac.doFinal();
+    }
+
+    /** Version 2: exactly length characters, extended from chained HMAC blocks. */
+    static String buildSynthLength(String seed, String period, byte[] entropy, int length) throws Exception {
+        return buildSynthLength(entropy, length, (prev, n) -> chainBlock(seed, period, prev, n));
+    }
+
+    static String buildSynthLength(byte[] entropy, int length, NextBlock next) throws Exception {
+        // All but the last ACTIVE_TAIL characters are settled and never rescanned.
+        final int ACTIVE_TAIL = 32;
+        List<byte[]> blocks = new ArrayList<>(List.of(entropy));
+        StringBuilder done = new StringBuilder(length);
+        String slug = synthCore(entropy);
+        for (int i = 0; ; i++) {
+            while (blocks.size() <= i + 1) {
+                blocks.add(next.next(blocks.get(blocks.size() - 1), blocks.size()));
+            }
+            slug = removeTriples(removeBlocked(slug, blocks.get(i)));
+            int n = length - done.length();
+            if (slug.length() >= n) {
+                if (slug.charAt(n - 1) != '-') return done.append(slug, 0, n).toString();
+                // The replacement of a trailing dash is filtered like any chunk.
+                offset = 31;
+                slug = slug.substring(0, n - 1) + pick(entropy, CONSONANTS);
+                continue;
             }
+            if (slug.length() > ACTIVE_TAIL) {
+                int settled = slug.length() - ACTIVE_TAIL;
+                done.append(slug, 0, settled);
+                slug = slug.substring(settled);
+            }
+            byte[] e = blocks.get(i + 1);
+            offset = 0;
+            String word = pick(e, WORDS);
+            if ((e[offset] & 0xFF) % 5 == 0) word = shorten(word);
+            offset++;
+            slug += word + syllable(e);
         }
-
-        return cleaned.toString();
     }
 
     static String[] entropyToWords(byte[] entropy) throws Exception {
@@ -214,23 +306,126 @@ public class TimeSlug {
         return words;
     }
 
-    static String[] derive(String seed, String period, int length, String mode) throws Exception {
+    /** Period string for a multiple interval (e.g. 900 for 15m) counted from epoch. */
+    static String bucketPeriod(long unixTime, long interval, long epoch) {
+        long start = epoch + Math.floorDiv(unixTime - epoch, interval) * interval;
+
+        String pattern = "yyyy-MM-dd'T'HH:mm:ss";
+        long[] formatUnits = {86400, 3600, 60};
+        String[] patterns = {"yyyy-MM-dd", "yyyy-MM-dd'T'HH", "yyyy-MM-dd'T'HH:mm"};
+        for (int i = 0; i < formatUnits.length; i++) {
+            if (interval % formatUnits[i] == 0 && epoch % formatUnits[i] == 0) {
+                pattern = patterns[i];
+                break;
+            }
+        }
+        String name = "";
+        long[] units = {604800, 86400, 3600, 60, 1};
+        String[] suffixes = {"w", "d", "h", "m", "s"};
+        for (int i = 0; i < units.length; i++) {
+            if (interval % units[i] == 0) {
+                name = (interval / units[i]) + suffixes[i];
+                break;
+            }
+        }
+
+        DateTimeFormatter formatter = DateTimeFormatter.ofPattern(pattern).withZone(ZoneOffset.UTC);
+        return formatter.format(Instant.ofEpochSecond(start)) + "/" + name;
+    }
+
+    /** Case words (lower, title, camel or upper) and join them with separator. */
+    static String joinWords(String[] words, String separator, String wordCase) {
+        wordCase = wordCase.toLowerCase(Locale.ROOT);
+        if (!List.of("lower", "title", "camel", "upper").contains(wordCase))
+            throw new IllegalArgumentException("invalid case: " + wordCase);
+        StringBuilder sb = new StringBuilder();
+        for (int i = 0; i < words.length; i++) {
+            String w = words[i];
+            if (wordCase.equals("upper")) {
+                w = w.toUpperCase(Locale.ROOT);
+            } else {
+                w = w.toLowerCase(Locale.ROOT);
+                if (!wordCase.equals("lower") && (wordCase.equals("title") || i > 0) && !w.isEmpty()) {
+                    w = w.substring(0, 1).toUpperCase(Locale.ROOT) + w.substring(1);
+                }
+            }
+            if (i > 0) sb.append(separator);
+            sb.append(w);
+        }
+        return sb.toString();
+    }
+
+    static String[] derive(String seed, String period, int length, String mode, int version) throws Exception {
+        return derive(seed, period, length, mode, version, "", "lower", "");
+    }
+
+    static String[] derive(String seed, String period, int length, String mode, int version,
+                           String separator, String wordCase, String namespace) throws Exception {
+        if (version < 1 || version > 3) throw new IllegalArgumentException("invalid algorithm version: " + version);
+        if (version == 3) return deriveV3(seed, period, length, mode, separator, wordCase, namespace);
+        if (!namespace.isEmpty()) {
+            throw new IllegalArgumentException("invalid namespace: algorithm version " + version + " does not derive with a namespace");
+        }
         byte[] entropy = hmacHash(seed, seed + ":" + period);
 
         if (mode.equalsIgnoreCase("obfuscated")) {
-            String value = buildSynth(entropy);
+            String value = version == 2 ? buildSynthLength(seed, period, entropy, length) : buildSynth(entropy);
             byte[] altHash = hmacHash(seed, seed + ":skid:" + period);
             int hashLen = Math.min((length + 1) / 2, 16);
             return new String[]{value, bytesToHex(altHash, hashLen)};
         }
 
-        // BIP39 mode
-        String[] words = entropyToWords(entropy);
-        int wordCount = Math.min(length, 24);
-        int hashLen = (wordCount * 11 + 7) / 8;
-        StringBuilder sb = new StringBuilder();
-        for (int i = 0; i < wordCount; i++) sb.append(words[i]);
-        return new String[]{sb.toString(), bytesToHex(entropy, hashLen)};
+        // BIP39 mode: 24 words per block of the entropy chain
+        if (version == 1 && length > 24) {
+            throw new IllegalArgumentException("algorithm version 1 derives at most 24 words: " + length);
+        }
+        List<String> words = new ArrayList<>(Arrays.asList(entropyToWords(entropy)));
+        byte[] block = entropy;
+        while (words.size() < length) {
+            block = chainBlock(seed, period, block, words.size() / 24);
+            words.addAll(Arrays.asList(entropyToWords(block)));
+        }
+        int hashLen = Math.min((length * 11 + 7) / 8, 32);
+        String slug = joinWords(words.subList(0, length).toArray(new String[0]), separator, wordCase);
+        return new String[]{slug, bytesToHex(entropy, hashLen)};
+    }
+
+    /** Version 3: version 2 slugs from HKDF subkeys for the slug and the hash. */
+    static String[] deriveV3(String seed, String period, int length, String mode,
+                             String separator, String wordCase, String namespace) throws Exception {
+        byte[] prk = hkdfExtract(seed);
+        mode = mode.equalsIgnoreCase("obfuscated") ? "obfuscated" : "bip39";
+        byte[] info = hkdfInfo("slug", mode, namespace, period);
+        NextBlock next = (prev, n) -> hkdfBlock(prk, info, prev, n);
+        byte[] entropy = next.next(new byte[0], 0);
+
+        String value;
+        int hashLen;
+        if (mode.equals("obfuscated")) {
+            value = buildSynthLength(entropy, length, next);
+            hashLen = Math.min((length + 1) / 2, 16);
+        } else {
+            List<String> words = new ArrayList<>(Arrays.asList(entropyToWords(entropy)));
+            byte[] block = entropy;
+            while (words.size() < length) {
+                block = next.next(block, words.size() / 24);
+                words.addAll(Arrays.asList(entropyToWords(block)));
+            }
+            value = joinWords(words.subList(0, length).toArray(new String[0]), separator, wordCase);
+            hashLen = Math.min((length * 11 + 7) / 8, 32);
+        }
+
+        // HKDF-Expand of the hash subkey
+        byte[] hashInfo = hkdfInfo("hash", mode, namespace, period);
+        byte[] hash = new byte[0];
+        byte[] block = new byte[0];
+        for (int n = 0; hash.length < hashLen; n++) {
+            block = hkdfBlock(prk, hashInfo, block, n);
+            byte[] longer = Arrays.copyOf(hash, hash.length + block.length);
+            System.arraycopy(block, 0, longer, hash.length, block.length);
+            hash = longer;
+        }
+        return new String[]{value, bytesToHex(hash, hashLen)};
     }
 
     static String bytesToHex(byte[] bytes, int len) {
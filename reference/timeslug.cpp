@@ -8,11 +8,15 @@
 #include <openssl/hmac.h>
 #include <openssl/sha.h>
 #include <algorithm>
+#include <cctype>
 #include <cstring>
+#include <ctime>
 #include <fstream>
+#include <functional>
 #include <iomanip>
 #include <iostream>
 #include <sstream>
+#include <stdexcept>
 #include <string>
 #include <vector>
 
@@ -58,8 +62,11 @@ std::string pick(const std::vector<unsigned char>& entropy, const std::vector<st
     return choices[idx];
 }
 
+// The operands of + are unsequenced, so each pick is its own statement.
 std::string syllable(const std::vector<unsigned char>& entropy) {
-    return pick(entropy, CONSONANTS) + pick(entropy, VOWELS) + pick(entropy, CODAS);
+    std::string c = pick(entropy, CONSONANTS);
+    std::string v = pick(entropy, VOWELS);
+    return c + v + pick(entropy, CODAS);
 }
 
 std::string shorten(const std::string& word) {
@@ -83,8 +90,7 @@ bool hasBlocked(const std::string& s) {
     return false;
 }
 
-std::string buildSynth(const std::vector<unsigned char>& entropy) {
-    const int MIN_LEN = 10, MAX_LEN = 18;
+std::string synthCore(const std::vector<unsigned char>& entropy) {
     offset = 0;
     std::string result;
 
@@ -132,20 +138,15 @@ std::string buildSynth(const std::vector<unsigned char>& entropy) {
     } else if (et <= 4) {
         result += pick(entropy, NUMBERS);
     } else if (et <= 6) {
-        result += syllable(entropy) + syllable(entropy);
+        result += syllable(entropy);
+        result += syllable(entropy);
     } else {
         result += pick(entropy, SUFFIXES);
     }
+    return result;
+}
 
-    // Pad
-    int po = 20;
-    while ((int)result.length() < MIN_LEN) {
-        offset = po;
-        result += syllable(entropy);
-        po = offset;
-    }
-
-    // Fix blocked
+std::string removeBlocked(std::string result, const std::vector<unsigned char>& entropy) {
     for (int i = 0; hasBlocked(result) && i < 10; i++) {
         std::string lower = result;
         std::transform(lower.begin(), lower.end(), lower.begin(), ::tolower);
@@ -159,6 +160,34 @@ std::string buildSynth(const std::vector<unsigned char>& entropy) {
             }
         }
     }
+    return result;
+}
+
+std::string removeTriples(const std::string& result) {
+    std::string cleaned;
+    for (size_t i = 0; i < result.length(); i++) {
+        char c = result[i];
+        if (i < 2 || !(c == result[i-1] && c == result[i-2])) {
+            cleaned += c;
+        }
+    }
+    return cleaned;
+}
+
+// Version 1: 10 to 18 characters regardless of length.
+std::string buildSynth(const std::vector<unsigned char>& entropy) {
+    const int MIN_LEN = 10, MAX_LEN = 18;
+    std::string result = synthCore(entropy);
+
+    // Pad
+    int po = 20;
+    while ((int)result.length() < MIN_LEN) {
+        offset = po;
+        result += syllable(entropy);
+        po = offset;
+    }
+
+    result = removeBlocked(result, entropy);
 
     // Truncate
     if ((int)result.length() > MAX_LEN) {
@@ -171,16 +200,90 @@ std::string buildSynth(const std::vector<unsigned char>& entropy) {
         if ((int)result.length() > MAX_LEN) result = result.substr(0, MAX_LEN);
     }
 
-    // Remove triple letters
-    std::string cleaned;
-    for (size_t i = 0; i < result.length(); i++) {
-        char c = result[i];
-        if (i < 2 || !(c == result[i-1] && c == result[i-2])) {
-            cleaned += c;
-        }
+    return removeTriples(result);
+}
+
+// Block n of the entropy chain: HMAC(seed, block n-1 + seed:period + byte(n)).
+std::vector<unsigned char> chainBlock(const std::string& seed, const std::string& period,
+                                      const std::vector<unsigned char>& prev, size_t n) {
+    std::string msg(prev.begin(), prev.end());
+    msg += seed + ":" + period;
+    msg += static_cast<char>(n & 0xff);
+    return hmacHash(seed, msg);
+}
+
+using NextBlock = std::function<std::vector<unsigned char>(const std::vector<unsigned char>&, size_t)>;
+
+const std::string HKDF_SALT = "timeslug/v3";
+
+// HKDF-Extract (RFC 5869) of the seed with the version 3 salt.
+std::string hkdfExtract(const std::string& seed) {
+    auto prk = hmacHash(HKDF_SALT, seed);
+    return std::string(prk.begin(), prk.end());
+}
+
+// Version 3 HKDF info: salt, purpose, mode, namespace and period joined by NUL.
+std::string hkdfInfo(const std::string& purpose, const std::string& mode, const std::string& ns,
+                     const std::string& period) {
+    std::string info = HKDF_SALT;
+    for (const auto& part : {purpose, mode, ns, period}) {
+        info += '\0';
+        info += part;
     }
+    return info;
+}
 
-    return cleaned;
+// Block n (from 0) of HKDF-Expand: HMAC(prk, block n-1 + info + byte(n+1)).
+std::vector<unsigned char> hkdfBlock(const std::string& prk, const std::string& info,
+                                     const std::vector<unsigned char>& prev, size_t n) {
+    std::string msg(prev.begin(), prev.end());
+    msg += info;
+    msg += static_cast<char>((n + 1) & 0xff);
+    return hmacHash(prk, msg);
+}
+
+// Version 2: exactly length characters, extended from chained HMAC blocks
+// computed by next, the entropy chain by default.
+std::string buildSynthLength(const std::string& seed, const std::string& period,
+                             const std::vector<unsigned char>& entropy, int length,
+                             NextBlock next = nullptr) {
+    if (!next) {
+        next = [&](const std::vector<unsigned char>& prev, size_t n) { return chainBlock(seed, period, prev, n); };
+    }
+    std::vector<std::vector<unsigned char>> blocks = {entropy};
+    auto block = [&](size_t i) {
+        while (blocks.size() <= i) {
+            blocks.push_back(next(blocks.back(), blocks.size()));
+        }
+        return blocks[i];
+    };
+
+    // All but the last ACTIVE_TAIL characters are settled and never rescanned.
+    const size_t ACTIVE_TAIL = 32;
+    std::string done;
+    std::string result = synthCore(entropy);
+    for (size_t i = 0;; i++) {
+        result = removeTriples(removeBlocked(result, block(i)));
+        size_t n = length - done.length();
+        if (result.length() >= n) {
+            if (result[n - 1] != '-') return done + result.substr(0, n);
+            // The replacement of a trailing dash is filtered like any chunk.
+            offset = 31;
+            result = result.substr(0, n - 1) + pick(entropy, CONSONANTS);
+            continue;
+        }
+        if (result.length() > ACTIVE_TAIL) {
+            size_t settled = result.length() - ACTIVE_TAIL;
+            done += result.substr(0, settled);
+            result = result.substr(settled);
+        }
+        auto e = block(i + 1);
+        offset = 0;
+        std::string word = pick(e, WORDS);
+        if (e[offset] % 5 == 0) word = shorten(word);
+        offset++;
+        result += word + syllable(e);
+    }
 }
 
 std::vector<std::string> entropyToWords(const std::vector<unsigned char>& entropy) {
@@ -218,28 +321,127 @@ std::string bytesToHex(const std::vector<unsigned char>& bytes, int len) {
     return ss.str();
 }
 
-std::pair<std::string, std::string> derive(const std::string& seed, const std::string& period, int length, const std::string& mode) {
+// Period string for a multiple interval (e.g. 900 for 15m) counted from epoch.
+std::string bucketPeriod(long long unixTime, long long interval, long long epoch = 0) {
+    long long diff = unixTime - epoch;
+    long long n = diff / interval - (diff % interval < 0 ? 1 : 0);
+    time_t start = epoch + n * interval;
+
+    const char* fmt = "%Y-%m-%dT%H:%M:%S";
+    const std::pair<long long, const char*> formats[] = {{86400, "%Y-%m-%d"}, {3600, "%Y-%m-%dT%H"}, {60, "%Y-%m-%dT%H:%M"}};
+    for (const auto& [unit, f] : formats) {
+        if (interval % unit == 0 && epoch % unit == 0) {
+            fmt = f;
+            break;
+        }
+    }
+    std::string name;
+    const std::pair<long long, const char*> suffixes[] = {{604800, "w"}, {86400, "d"}, {3600, "h"}, {60, "m"}, {1, "s"}};
+    for (const auto& [unit, suffix] : suffixes) {
+        if (interval % unit == 0) {
+            name = std::to_string(interval / unit) + suffix;
+            break;
+        }
+    }
+
+    std::tm tm{};
+    gmtime_r(&start, &tm);
+    char buf[32];
+    strftime(buf, sizeof(buf), fmt, &tm);
+    return std::string(buf) + "/" + name;
+}
+
+// Case words (lower, title, camel or upper) and join them with separator.
+std::string joinWords(const std::vector<std::string>& words, const std::string& separator, std::string wordCase) {
+    std::transform(wordCase.begin(), wordCase.end(), wordCase.begin(), ::tolower);
+    if (wordCase != "lower" && wordCase != "title" && wordCase != "camel" && wordCase != "upper")
+        throw std::invalid_argument("invalid case: " + wordCase);
+    std::string result;
+    for (size_t i = 0; i < words.size(); i++) {
+        std::string w = words[i];
+        if (wordCase == "upper") {
+            std::transform(w.begin(), w.end(), w.begin(), ::toupper);
+        } else {
+            std::transform(w.begin(), w.end(), w.begin(), ::tolower);
+            if (wordCase != "lower" && (wordCase == "title" || i > 0) && !w.empty()) w[0] = std::toupper(w[0]);
+        }
+        if (i > 0) result += separator;
+        result += w;
+    }
+    return result;
+}
+
+// Version 3: version 2 slugs from HKDF subkeys for the slug and the hash.
+std::pair<std::string, std::string> deriveV3(const std::string& seed, const std::string& period, int length,
+                                             std::string mode, const std::string& separator,
+                                             const std::string& wordCase, const std::string& ns = "") {
+    auto prk = hkdfExtract(seed);
+    if (mode != "obfuscated") mode = "bip39";
+    auto info = hkdfInfo("slug", mode, ns, period);
+    NextBlock next = [&](const std::vector<unsigned char>& prev, size_t n) { return hkdfBlock(prk, info, prev, n); };
+    auto entropy = next({}, 0);
+
+    std::string value;
+    int hashLen;
+    if (mode == "obfuscated") {
+        value = buildSynthLength(seed, period, entropy, length, next);
+        hashLen = std::min((length + 1) / 2, 16);
+    } else {
+        auto words = entropyToWords(entropy);
+        auto block = entropy;
+        while ((int)words.size() < length) {
+            block = next(block, words.size() / 24);
+            auto more = entropyToWords(block);
+            words.insert(words.end(), more.begin(), more.end());
+        }
+        words.resize(length);
+        value = joinWords(words, separator, wordCase);
+        hashLen = std::min((length * 11 + 7) / 8, 32);
+    }
+
+    // HKDF-Expand of the hash subkey
+    auto hashInfo = hkdfInfo("hash", mode, ns, period);
+    std::vector<unsigned char> hash, block;
+    for (size_t n = 0; (int)hash.size() < hashLen; n++) {
+        block = hkdfBlock(prk, hashInfo, block, n);
+        hash.insert(hash.end(), block.begin(), block.end());
+    }
+    return {value, bytesToHex(hash, hashLen)};
+}
+
+std::pair<std::string, std::string> derive(const std::string& seed, const std::string& period, int length,
+                                           const std::string& mode, int version = 1,
+                                           const std::string& separator = "", const std::string& wordCase = "lower",
+                                           const std::string& ns = "") {
+    if (version < 1 || version > 3) throw std::invalid_argument("invalid algorithm version");
+    if (version == 3) return deriveV3(seed, period, length, mode, separator, wordCase, ns);
+    if (!ns.empty()) throw std::invalid_argument("only algorithm version 3 derives with a namespace");
     auto entropy = hmacHash(seed, seed + ":" + period);
 
     if (mode == "obfuscated") {
-        std::string value = buildSynth(entropy);
+        std::string value = version == 2 ? buildSynthLength(seed, period, entropy, length) : buildSynth(entropy);
         auto altHash = hmacHash(seed, seed + ":skid:" + period);
         int hashLen = std::min((length + 1) / 2, 16);
         return {value, bytesToHex(altHash, hashLen)};
     }
 
-    // BIP39 mode
+    // BIP39 mode: 24 words per block of the entropy chain
+    if (version == 1 && length > 24) throw std::invalid_argument("algorithm version 1 derives at most 24 words");
     auto words = entropyToWords(entropy);
-    int wordCount = std::min(length, 24);
-    int hashLen = (wordCount * 11 + 7) / 8;
-    std::string slug;
-    for (int i = 0; i < wordCount; i++) slug += words[i];
-    return {slug, bytesToHex(entropy, hashLen)};
+    auto block = entropy;
+    while ((int)words.size() < length) {
+        block = chainBlock(seed, period, block, words.size() / 24);
+        auto more = entropyToWords(block);
+        words.insert(words.end(), more.begin(), more.end());
+    }
+    int hashLen = std::min((length * 11 + 7) / 8, 32);
+    words.resize(length);
+    return {joinWords(words, separator, wordCase), bytesToHex(entropy, hashLen)};
 }
 
 int main(int argc, char* argv[]) {
     // Load BIP39 wordlist
-    std::ifstream file("../internal/provider/bip39_english.txt");
+    std::ifstream file("../timeslug/bip39_english.txt");
     std::string line;
     while (std::getline(file, line)) {
         bip39Words.push_back(line);
@@ -249,8 +451,12 @@ int main(int argc, char* argv[]) {
     std::string period = argc > 2 ? argv[2] : "2026-02-03";
     std::string mode = argc > 3 ? argv[3] : "obfuscated";
     int length = argc > 4 ? std::stoi(argv[4]) : 16;
+    int version = argc > 5 ? std::stoi(argv[5]) : 1;
+    std::string separator = argc > 6 ? argv[6] : "";
+    std::string wordCase = argc > 7 ? argv[7] : "lower";
+    std::string ns = argc > 8 ? argv[8] : "";
 
-    auto [slug, hash] = derive(seed, period, length, mode);
+    auto [slug, hash] = derive(seed, period, length, mode, version, separator, wordCase, ns);
     std::cout << "Mode:   " << mode << std::endl;
     std::cout << "Period: " << period << std::endl;
     std::cout << "Slug:   " << slug << std::endl;
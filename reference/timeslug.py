@@ -7,6 +7,7 @@ Generates deterministic slugs in two modes: BIP39 (words) and Obfuscated (synth)
 import hashlib
 import hmac
 import sys
+from datetime import datetime, timezone
 
 # BIP39 wordlist (first 20 shown, full list in production)
 BIP39_WORDS = open('bip39_english.txt').read().strip().split('\n') if __name__ != '__main__' else None
@@ -75,9 +76,8 @@ def has_blocked(s: str) -> bool:
     return any(b in lower for b in BLOCKED)
 
 
-def build_synth(entropy: bytes) -> str:
-    """Build obfuscated slug using layered construction."""
-    MIN_LEN, MAX_LEN = 10, 18
+def synth_core(entropy: bytes) -> str:
+    """Build the layers of an obfuscated slug."""
     o = 0
     result = []
 
@@ -132,15 +132,11 @@ def build_synth(entropy: bytes) -> str:
         suf, _ = pick(entropy, o + 1, SUFFIXES)
         result.append(suf)
 
-    slug = ''.join(result)
+    return ''.join(result)
 
-    # Pad
-    po = 20
-    while len(slug) < MIN_LEN:
-        syl, po = syllable(entropy, po)
-        slug += syl
 
-    # Fix blocked
+def remove_blocked(slug: str, entropy: bytes) -> str:
+    """Break up blocked words with syllables."""
     for i in range(10):
         if not has_blocked(slug):
             break
@@ -150,6 +146,30 @@ def build_synth(entropy: bytes) -> str:
                 syl, _ = syllable(entropy, 25 + i)
                 slug = slug[:idx] + syl + slug[idx:]
                 break
+    return slug
+
+
+def remove_triples(slug: str) -> str:
+    """Drop letters repeated three times in a row."""
+    cleaned = []
+    for i, c in enumerate(slug):
+        if i < 2 or not (c == slug[i-1] == slug[i-2]):
+            cleaned.append(c)
+    return ''.join(cleaned)
+
+
+def build_synth(entropy: bytes) -> str:
+    """Build version 1 obfuscated slug of 10 to 18 characters."""
+    MIN_LEN, MAX_LEN = 10, 18
+    slug = synth_core(entropy)
+
+    # Pad
+    po = 20
+    while len(slug) < MIN_LEN:
+        syl, po = syllable(entropy, po)
+        slug += syl
+
+    slug = remove_blocked(slug, entropy)
 
     # Truncate
     if len(slug) > MAX_LEN:
@@ -160,13 +180,86 @@ def build_synth(entropy: bytes) -> str:
         else:
             slug = slug[:MAX_LEN]
 
-    # Remove triple letters
-    cleaned = []
-    for i, c in enumerate(slug):
-        if i < 2 or not (c == slug[i-1] == slug[i-2]):
-            cleaned.append(c)
+    return remove_triples(slug)
+
+
+def chain_block(seed: str, period: str, prev: bytes, n: int) -> bytes:
+    """Block n of the entropy chain: HMAC(seed, block n-1 + seed:period + byte(n))."""
+    return hmac.new(seed.encode(), prev + f"{seed}:{period}".encode() + bytes([n % 256]), hashlib.sha256).digest()
+
+
+ACTIVE_TAIL = 32
+
+
+def build_synth_length(seed: str, period: str, entropy: bytes, length: int, next_block=None) -> str:
+    """Build version 2 obfuscated slug of exactly length characters.
+
+    next_block(prev, n) computes block n of the chain, default chain_block.
+    """
+    if next_block is None:
+        next_block = lambda prev, n: chain_block(seed, period, prev, n)
+    blocks = [entropy]
+
+    def block(i):
+        while len(blocks) <= i:
+            blocks.append(next_block(blocks[-1], len(blocks)))
+        return blocks[i]
+
+    # All but the last ACTIVE_TAIL characters are settled and never rescanned.
+    done = []
+    done_len = 0
+    slug = synth_core(entropy)
+    i = 0
+    while True:
+        slug = remove_triples(remove_blocked(slug, block(i)))
+        i += 1
+        n = length - done_len
+        if len(slug) >= n:
+            if slug[n - 1] != '-':
+                done.append(slug[:n])
+                return ''.join(done)
+            # The replacement of a trailing dash is filtered like any chunk.
+            c, _ = pick(entropy, 31, CONSONANTS)
+            slug = slug[:n - 1] + c
+            continue
+        settled = len(slug) - ACTIVE_TAIL
+        if settled > 0:
+            done.append(slug[:settled])
+            done_len += settled
+            slug = slug[settled:]
+        e = block(i)
+        word, o = pick(e, 0, WORDS)
+        if e[o] % 5 == 0:
+            word = shorten(word)
+        syl, _ = syllable(e, o + 1)
+        slug += word + syl
+
+
+HKDF_SALT = 'timeslug/v3'
 
-    return ''.join(cleaned)
+
+def hkdf_extract(seed: str) -> bytes:
+    """HKDF-Extract (RFC 5869) of the seed with the version 3 salt."""
+    return hmac.new(HKDF_SALT.encode(), seed.encode(), hashlib.sha256).digest()
+
+
+def hkdf_block(prk: bytes, info: bytes, prev: bytes, n: int) -> bytes:
+    """Block n (from 0) of HKDF-Expand: HMAC(prk, block n-1 + info + byte(n+1))."""
+    return hmac.new(prk, prev + info + bytes([(n + 1) % 256]), hashlib.sha256).digest()
+
+
+def hkdf_info(purpose: str, mode: str, namespace: str, period: str) -> bytes:
+    """Version 3 HKDF info: salt, purpose, mode, namespace and period joined by NUL."""
+    return '\0'.join([HKDF_SALT, purpose, mode, namespace, period]).encode()
+
+
+def hkdf_expand(prk: bytes, info: bytes, length: int) -> bytes:
+    """HKDF-Expand (RFC 5869) of length bytes."""
+    out, block = b'', b''
+    while len(out) < length:
+        block = hkdf_block(prk, info, block, len(out) // 32)
+        out += block
+    return out[:length]
 
 
 def entropy_to_words(entropy: bytes, bip39_words: list) -> list:
@@ -192,28 +285,102 @@ def entropy_to_words(entropy: bytes, bip39_words: list) -> list:
     return words
 
 
-def derive(seed: str, period: str, length: int, mode: str, bip39_words: list = None):
-    """Generate slug and hash for given seed/period."""
+def bucket_period(unix_time: int, interval: int, epoch: int = 0) -> str:
+    """Period string for a multiple interval (e.g. 900 for 15m) counted from epoch."""
+    start = epoch + (unix_time - epoch) // interval * interval
+    fmt = '%Y-%m-%dT%H:%M:%S'
+    for unit, f in ((86400, '%Y-%m-%d'), (3600, '%Y-%m-%dT%H'), (60, '%Y-%m-%dT%H:%M')):
+        if interval % unit == 0 and epoch % unit == 0:
+            fmt = f
+            break
+    for unit, suffix in ((604800, 'w'), (86400, 'd'), (3600, 'h'), (60, 'm'), (1, 's')):
+        if interval % unit == 0:
+            name = f'{interval // unit}{suffix}'
+            break
+    # %Y does not pad years before 1000 on every platform.
+    dt = datetime.fromtimestamp(start, timezone.utc)
+    return f'{dt.year:04d}' + dt.strftime(fmt[2:]) + '/' + name
+
+
+def join_words(words: list, separator: str = '', case: str = 'lower') -> str:
+    """Case words (lower, title, camel or upper) and join them with separator."""
+    case = case.lower()
+    if case not in ('lower', 'title', 'camel', 'upper'):
+        raise ValueError(f"invalid case: {case}")
+    cased = []
+    for i, w in enumerate(words):
+        if case == 'lower':
+            w = w.lower()
+        elif case == 'upper':
+            w = w.upper()
+        else:
+            w = w.lower()
+            if case == 'title' or i > 0:
+                w = w[:1].upper() + w[1:]
+        cased.append(w)
+    return separator.join(cased)
+
+
+def derive(seed: str, period: str, length: int, mode: str, bip39_words: list = None, version: int = 1,
+           separator: str = '', case: str = 'lower', namespace: str = ''):
+    """Generate slug and hash for given seed/period with an algorithm version."""
+    if version not in (1, 2, 3):
+        raise ValueError(f"invalid algorithm version: {version}")
+    if version == 3:
+        return derive_v3(seed, period, length, mode, bip39_words, separator, case, namespace)
+    if namespace:
+        raise ValueError(f"invalid namespace: algorithm version {version} does not derive with a namespace")
     entropy = hmac_hash(seed, f"{seed}:{period}")
 
     if mode.lower() == 'obfuscated':
-        value = build_synth(entropy)
+        if version == 2:
+            value = build_synth_length(seed, period, entropy, length)
+        else:
+            value = build_synth(entropy)
         alt_hash = hmac_hash(seed, f"{seed}:skid:{period}")
         hash_len = min((length + 1) // 2, 16)
         return value, alt_hash[:hash_len].hex()
 
-    # BIP39 mode
+    # BIP39 mode: 24 words per block of the entropy chain
+    if version == 1 and length > 24:
+        raise ValueError(f"invalid length: {length} words, algorithm version 1 derives at most 24")
     words = entropy_to_words(entropy, bip39_words)
-    word_count = min(length, 24)
-    hash_len = (word_count * 11 + 7) // 8
-    return ''.join(words[:word_count]), entropy[:hash_len].hex()
+    block = entropy
+    while len(words) < length:
+        block = chain_block(seed, period, block, len(words) // 24)
+        words += entropy_to_words(block, bip39_words)
+    hash_len = min((length * 11 + 7) // 8, 32)
+    return join_words(words[:length], separator, case), entropy[:hash_len].hex()
+
+
+def derive_v3(seed: str, period: str, length: int, mode: str, bip39_words: list,
+              separator: str = '', case: str = 'lower', namespace: str = ''):
+    """Version 3: version 2 slugs from HKDF subkeys for the slug and the hash."""
+    prk = hkdf_extract(seed)
+    mode = 'obfuscated' if mode.lower() == 'obfuscated' else 'bip39'
+    info = hkdf_info('slug', mode, namespace, period)
+    next_block = lambda prev, n: hkdf_block(prk, info, prev, n)
+    entropy = next_block(b'', 0)
+
+    if mode == 'obfuscated':
+        value = build_synth_length(seed, period, entropy, length, next_block)
+        hash_len = min((length + 1) // 2, 16)
+    else:
+        words = entropy_to_words(entropy, bip39_words)
+        block = entropy
+        while len(words) < length:
+            block = next_block(block, len(words) // 24)
+            words += entropy_to_words(block, bip39_words)
+        value = join_words(words[:length], separator, case)
+        hash_len = min((length * 11 + 7) // 8, 32)
+    return value, hkdf_expand(prk, hkdf_info('hash', mode, namespace, period), hash_len).hex()
 
 
 if __name__ == '__main__':
     # Load BIP39 wordlist
     import os
     script_dir = os.path.dirname(os.path.abspath(__file__))
-    bip39_path = os.path.join(script_dir, '..', 'internal', 'provider', 'bip39_english.txt')
+    bip39_path = os.path.join(script_dir, '..', 'timeslug', 'bip39_english.txt')
     with open(bip39_path) as f:
         bip39_words = f.read().strip().split('\n')
 
@@ -221,8 +388,12 @@ if __name__ == '__main__':
     period = sys.argv[2] if len(sys.argv) > 2 else '2026-02-03'
     mode = sys.argv[3] if len(sys.argv) > 3 else 'obfuscated'
     length = int(sys.argv[4]) if len(sys.argv) > 4 else 16
+    version = int(sys.argv[5]) if len(sys.argv) > 5 else 1
+    separator = sys.argv[6] if len(sys.argv) > 6 else ''
+    case = sys.argv[7] if len(sys.argv) > 7 else 'lower'
+    namespace = sys.argv[8] if len(sys.argv) > 8 else ''
 
-    slug, hash_val = derive(seed, period, length, mode, bip39_words)
+    slug, hash_val = derive(seed, period, length, mode, bip39_words, version, separator, case, namespace)
     print(f"Mode:   {mode}")
     print(f"Period: {period}")
     print(f"Slug:   {slug}")
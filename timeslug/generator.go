@@ -0,0 +1,335 @@
+// Package timeslug generates deterministic, time-rotating slugs from a
+// secret seed. It is the engine behind the timeslug Terraform provider and
+// CLI, so services importing it derive exactly the same slugs.
+package timeslug
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Output modes.
+const (
+	ModeBIP39      = "bip39"
+	ModeObfuscated = "obfuscated"
+)
+
+// Word cases of BIP39 slugs.
+const (
+	CaseLower = "lower" // exoticangryanswer
+	CaseTitle = "title" // ExoticAngryAnswer
+	CaseCamel = "camel" // exoticAngryAnswer
+	CaseUpper = "upper" // EXOTICANGRYANSWER
+)
+
+// Defaults used when the corresponding option is not given.
+const (
+	DefaultLength   = 3
+	DefaultInterval = "day"
+	DefaultMode     = ModeBIP39
+	DefaultVersion  = Version1
+	DefaultLanguage = LanguageEnglish
+	DefaultCase     = CaseLower
+)
+
+// Maximum slug lengths: words in bip39 mode, characters in obfuscated mode.
+const (
+	MaxWords      = 256
+	MaxCharacters = 1024
+)
+
+// MaxLength returns the maximum slug length of mode.
+func MaxLength(mode string) int {
+	if strings.EqualFold(mode, ModeObfuscated) {
+		return MaxCharacters
+	}
+	return MaxWords
+}
+
+var (
+	ErrInvalidTime      = errors.New("invalid time")
+	ErrInvalidInterval  = errors.New("invalid interval")
+	ErrInvalidWindow    = errors.New("invalid window")
+	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrInvalidVersion   = errors.New("invalid algorithm version")
+	ErrInvalidLength    = errors.New("invalid length")
+	ErrInvalidLanguage  = errors.New("invalid language")
+	ErrInvalidWordlist  = errors.New("invalid wordlist")
+	ErrInvalidCase      = errors.New("invalid case")
+	ErrInvalidMode      = errors.New("invalid mode")
+	ErrInvalidNamespace = errors.New("invalid namespace")
+)
+
+// Generator derives slugs from a seed with a fixed length, interval and
+// mode. It is immutable and safe for concurrent use.
+type Generator struct {
+	seed      string
+	length    int
+	interval  string
+	mode      string
+	version   int
+	language  string
+	wordlist  []string
+	sep       string
+	sepSet    bool
+	wordCase  string
+	caseSet   bool
+	namespace string
+	isoWeek   bool
+	epoch     time.Time
+	loc       *time.Location
+	// seeds are sorted by ValidFrom. Without WithSeeds, seeds holds the
+	// seed passed to New, valid for all time.
+	seeds []Seed
+
+	iv     interval
+	alg    algorithm
+	params params
+}
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// WithLength sets the slug length: words for bip39, at most 24 with
+// Version1, characters for obfuscated. See MaxLength.
+func WithLength(n int) Option {
+	return func(g *Generator) { g.length = n }
+}
+
+// WithInterval sets the rotation interval: second, minute, hour, day, week,
+// month, quarter or year, or a multiple such as 15m, 6h, 3d or 2w.
+func WithInterval(interval string) Option {
+	return func(g *Generator) { g.interval = interval }
+}
+
+// WithISOWeek makes the week interval use ISO 8601 week periods (2026-W06)
+// that rotate on Monday. It is off by default because the legacy week
+// periods, which change daily, produce different slugs.
+func WithISOWeek(enabled bool) Option {
+	return func(g *Generator) { g.isoWeek = enabled }
+}
+
+// WithEpoch sets the instant multiples such as 15m are counted from.
+// Default: the Unix epoch.
+func WithEpoch(epoch time.Time) Option {
+	return func(g *Generator) { g.epoch = epoch }
+}
+
+// WithVersion sets the algorithm version, one of Versions().
+func WithVersion(version int) Option {
+	return func(g *Generator) { g.version = version }
+}
+
+// WithLocation sets the time zone periods are computed in. Times passed to
+// the Generator are converted to it first. Default: UTC.
+func WithLocation(loc *time.Location) Option {
+	return func(g *Generator) { g.loc = loc }
+}
+
+// LoadLocation returns the IANA time zone name, UTC if name is empty.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
+
+// WithLanguage sets the BIP39 wordlist language, one of Languages().
+// Obfuscated slugs are not affected.
+func WithLanguage(language string) Option {
+	return func(g *Generator) { g.language = language }
+}
+
+// WithWordlist makes BIP39 mode draw words from a custom wordlist of at
+// least two distinct words instead of the language wordlist. Lists of any
+// size are supported; each word is equally likely.
+func WithWordlist(words []string) Option {
+	return func(g *Generator) { g.wordlist = words }
+}
+
+// WithSeparator sets the string joining the words of BIP39 slugs, for
+// languages whose words are unreadable when concatenated. Default:
+// DefaultSeparator of the language, or none with a custom wordlist.
+func WithSeparator(sep string) Option {
+	return func(g *Generator) { g.sep, g.sepSet = sep, true }
+}
+
+// WithCase sets the case of the words of BIP39 slugs: CaseLower, CaseTitle,
+// CaseCamel or CaseUpper. Obfuscated slugs are not affected. Default:
+// DefaultCase, or the words as given with a custom wordlist.
+func WithCase(c string) Option {
+	return func(g *Generator) { g.wordCase, g.caseSet = c, true }
+}
+
+// WithNamespace separates the slugs of services sharing a seed: slugs of
+// different namespaces are independent. It requires Version3, and the
+// default empty namespace derives the same slugs as no namespace.
+func WithNamespace(namespace string) Option {
+	return func(g *Generator) { g.namespace = namespace }
+}
+
+// WithMode sets the output mode, ModeBIP39 or ModeObfuscated.
+func WithMode(mode string) Option {
+	return func(g *Generator) { g.mode = mode }
+}
+
+// New returns a Generator for seed.
+func New(seed string, opts ...Option) (*Generator, error) {
+	g := &Generator{
+		seed:     seed,
+		length:   DefaultLength,
+		interval: DefaultInterval,
+		mode:     DefaultMode,
+		version:  DefaultVersion,
+		language: DefaultLanguage,
+		wordCase: DefaultCase,
+		epoch:    time.Unix(0, 0),
+		loc:      time.UTC,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	if g.loc == nil {
+		g.loc = time.UTC
+	}
+	if g.seeds == nil {
+		g.seeds = []Seed{{Value: seed}}
+	} else if err := checkSeeds(g.seeds); err != nil {
+		return nil, err
+	}
+	var err error
+	g.alg, err = lookupAlgorithm(g.version, g.length)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(g.mode, ModeBIP39) && !strings.EqualFold(g.mode, ModeObfuscated) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMode, g.mode)
+	}
+	if limit := MaxLength(g.mode); g.length > limit {
+		return nil, fmt.Errorf("%w: %d, %s slugs are at most %d long", ErrInvalidLength, g.length, strings.ToLower(g.mode), limit)
+	}
+	if g.namespace != "" && !g.alg.namespaces {
+		return nil, fmt.Errorf("%w: algorithm version %d does not derive with a namespace, use version %d", ErrInvalidNamespace, g.version, Version3)
+	}
+	if strings.ContainsRune(g.namespace, 0) {
+		return nil, fmt.Errorf("%w: %q contains NUL", ErrInvalidNamespace, g.namespace)
+	}
+	if !g.sepSet && g.wordlist == nil {
+		g.sep = DefaultSeparator(g.language)
+	}
+	g.params = params{length: g.length, mode: g.mode, namespace: g.namespace, separator: g.sep, wordCase: strings.ToLower(g.wordCase)}
+	switch g.params.wordCase {
+	case CaseLower, CaseTitle, CaseCamel, CaseUpper:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCase, g.wordCase)
+	}
+	if g.wordlist != nil {
+		g.params.words, err = checkWordlist(g.wordlist)
+		g.params.custom = true
+		if !g.caseSet {
+			// Custom words such as product names keep their case.
+			g.params.wordCase = ""
+		}
+	} else {
+		g.params.words, err = lookupWordlist(g.language)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if g.alg.maxWords > 0 && g.length > g.alg.maxWords && !g.params.custom && !strings.EqualFold(g.mode, ModeObfuscated) {
+		return nil, fmt.Errorf("%w: %d words, algorithm version %d derives at most %d", ErrInvalidLength, g.length, g.version, g.alg.maxWords)
+	}
+	g.iv, err = parseInterval(g.interval)
+	if err != nil {
+		return nil, err
+	}
+	g.iv.iso = g.isoWeek && g.iv.duration == week && g.iv.name == ""
+	g.iv = g.iv.withEpoch(g.epoch)
+	g.iv.zone = !isUTC(g.loc)
+	return g, nil
+}
+
+// isUTC reports whether loc is UTC under any name, such as Etc/UTC: a
+// zone without offset or transitions.
+func isUTC(loc *time.Location) bool {
+	t := time.Unix(0, 0).In(loc)
+	start, end := t.ZoneBounds()
+	_, offset := t.Zone()
+	return offset == 0 && start.IsZero() && end.IsZero()
+}
+
+// ISOWeek reports whether the periods are ISO 8601 weeks: WithISOWeek with
+// the week interval.
+func (g *Generator) ISOWeek() bool {
+	return g.iv.iso
+}
+
+// Multiple reports whether the interval is a multiple such as 15m, counted
+// from the epoch set with WithEpoch.
+func (g *Generator) Multiple() bool {
+	return g.iv.name != ""
+}
+
+// Namespace returns the namespace set with WithNamespace, or an empty string.
+func (g *Generator) Namespace() string {
+	return g.namespace
+}
+
+// WordlistDigest returns the WordlistDigest of the custom wordlist, or an
+// empty string if the Generator uses a BIP39 wordlist.
+func (g *Generator) WordlistDigest() string {
+	if !g.params.custom {
+		return ""
+	}
+	return WordlistDigest(g.params.words)
+}
+
+// ParseTime is like ParseTimeIn with the Generator's time zone.
+func (g *Generator) ParseTime(s string) (time.Time, error) {
+	return ParseTimeIn(s, g.loc)
+}
+
+// Derive creates the slug for a period string. With WithSeeds, it uses the
+// seed with the latest ValidFrom, since a period string carries no time to
+// pick a seed by; At picks the seed active for the period.
+func (g *Generator) Derive(period string) Slug {
+	return g.deriveWith(g.seeds[len(g.seeds)-1], period)
+}
+
+// At creates the slug for the period containing t.
+func (g *Generator) At(t time.Time) Slug {
+	t = t.In(g.loc)
+	return g.deriveWith(g.activeSeeds(g.iv.begin(t))[0], g.iv.period(t))
+}
+
+func (g *Generator) deriveWith(s Seed, period string) Slug {
+	value, hash := g.alg.derive(s.Value, period, g.params)
+	return Slug{Value: value, Period: period, Hash: hash, SeedID: s.ID, Namespace: g.namespace}
+}
+
+// Window creates n slugs for consecutive periods centered on anchor.
+func (g *Generator) Window(anchor time.Time, n int) ([]Slug, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidWindow, n)
+	}
+	slugs := make([]Slug, n)
+	start := -n / 2
+	for i := range slugs {
+		slugs[i] = g.At(g.iv.add(anchor.In(g.loc), start+i))
+	}
+	return slugs, nil
+}
+
+// Bounds returns the period containing t and the instant the next period
+// begins, i.e. when a slug pinned to t must rotate.
+func (g *Generator) Bounds(t time.Time) (string, time.Time) {
+	t = t.In(g.loc)
+	return g.iv.period(t), g.iv.next(t)
+}
@@ -0,0 +1,394 @@
+package timeslug
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestGeneratorCompatibility(t *testing.T) {
+	// The public API must reproduce the reference test vectors.
+	for _, tc := range testVectors {
+		g, err := New(tc.seed, WithLength(tc.length), WithMode(tc.mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.Derive(tc.period); got.Value != tc.slug || got.Hash != tc.hash || got.Period != tc.period {
+			t.Errorf("%s/%s: got %+v, want %q/%q", tc.period, tc.mode, got, tc.slug, tc.hash)
+		}
+		if len(tc.period) != len("2006-01-02") {
+			// Calendar periods are not timestamps.
+			continue
+		}
+		at, err := ParseTime(tc.period)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.At(at.Add(13 * time.Hour)); got.Value != tc.slug {
+			t.Errorf("At(%s): got %q, want %q", tc.period, got.Value, tc.slug)
+		}
+	}
+}
+
+func TestGeneratorDefaults(t *testing.T) {
+	g, err := New("seedphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03"); got.Value != "exoticangryanswer" {
+		t.Errorf("got %q", got.Value)
+	}
+}
+
+func TestGeneratorWindow(t *testing.T) {
+	g, err := New("seedphrase", WithLength(16), WithMode(ModeObfuscated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slugs, err := g.Window(time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"trybeambold8", "brightbeamvivar", "trycorefastfum"}
+	for i, s := range slugs {
+		if s.Value != want[i] {
+			t.Errorf("slugs[%d] = %q, want %q", i, s.Value, want[i])
+		}
+	}
+	if _, err := g.Window(time.Now(), -1); !errors.Is(err, ErrInvalidWindow) {
+		t.Errorf("expected ErrInvalidWindow, got %v", err)
+	}
+}
+
+func TestGeneratorISOWeekWindow(t *testing.T) {
+	g, err := New("seedphrase", WithInterval("week"), WithISOWeek(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slugs, err := g.Window(time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Slug{
+		{Value: "ketchupvitalplay", Period: "2026-W05", Hash: "79fea2996f"},
+		{Value: "garlicsolutiontackle", Period: "2026-W06", Hash: "5fd9db7439"},
+		{Value: "swingdogquantum", Period: "2026-W07", Hash: "dc0812bd2d"},
+	}
+	for i, s := range slugs {
+		if s != want[i] {
+			t.Errorf("slugs[%d] = %+v, want %+v", i, s, want[i])
+		}
+	}
+}
+
+func TestGeneratorMultipleWindow(t *testing.T) {
+	g, err := New("seedphrase", WithInterval("15m"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slugs, err := g.Window(time.Date(2026, 2, 3, 12, 20, 0, 0, time.UTC), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"2026-02-03T12:00/15m", "2026-02-03T12:15/15m", "2026-02-03T12:30/15m"}
+	for i, s := range slugs {
+		if s.Period != want[i] {
+			t.Errorf("slugs[%d].Period = %q, want %q", i, s.Period, want[i])
+		}
+	}
+	if slugs[1].Value != "sustainfortunegarment" {
+		t.Errorf("got %q", slugs[1].Value)
+	}
+}
+
+func TestGeneratorLocation(t *testing.T) {
+	// 23:00 in New York is already the next day in UTC.
+	anchor, err := ParseTime("2026-02-03T23:00:00-05:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := New("seedphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.At(anchor).Period; got != "2026-02-04" {
+		t.Errorf("UTC: got %q", got)
+	}
+
+	ny, err := LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	g, err = New("seedphrase", WithLocation(ny))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.At(anchor); got.Period != "2026-02-03" || got.Value != "exoticangryanswer" {
+		t.Errorf("New York: got %+v", got)
+	}
+	// Wall-clock anchors are interpreted in the zone.
+	local, err := ParseTimeIn("2026-02-03T23:00", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !local.Equal(anchor) {
+		t.Errorf("ParseTimeIn: got %s, want %s", local, anchor)
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	if loc, err := LoadLocation(""); err != nil || loc != time.UTC {
+		t.Errorf("got %v/%v", loc, err)
+	}
+	if _, err := LoadLocation("Nowhere/Special"); !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("expected ErrInvalidTimezone, got %v", err)
+	}
+}
+
+func TestGeneratorVersion(t *testing.T) {
+	g, err := New("seedphrase", WithMode(ModeObfuscated), WithLength(8), WithVersion(Version2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03"); got.Value != "trybeamb" || got.Hash != "5d3bf0d5" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New("seed", WithInterval("invalid")); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("expected ErrInvalidInterval, got %v", err)
+	}
+	if _, err := New("seed", WithVersion(0)); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("expected ErrInvalidVersion, got %v", err)
+	}
+	if _, err := New("seed", WithLength(0)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected ErrInvalidLength, got %v", err)
+	}
+	if _, err := New("seed", WithMode("words")); !errors.Is(err, ErrInvalidMode) {
+		t.Errorf("expected ErrInvalidMode, got %v", err)
+	}
+	if _, err := New("seed", WithMode("Obfuscated")); err != nil {
+		t.Error(err)
+	}
+	for _, mode := range []string{ModeBIP39, ModeObfuscated} {
+		if _, err := New("seed", WithMode(mode), WithVersion(Version2), WithLength(MaxLength(mode))); err != nil {
+			t.Error(err)
+		}
+		if _, err := New("seed", WithMode(mode), WithVersion(Version2), WithLength(MaxLength(mode)+1)); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("%s: expected ErrInvalidLength, got %v", mode, err)
+		}
+	}
+	if _, err := New("seed", WithWordlist([]string{"a", "b"}), WithLength(MaxWords+1)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("custom wordlist: expected ErrInvalidLength, got %v", err)
+	}
+	// Version1 derives at most 24 BIP39 words.
+	if _, err := New("seed", WithVersion(Version1), WithLength(25)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected ErrInvalidLength, got %v", err)
+	}
+	for _, opt := range []Option{WithVersion(Version2), WithMode(ModeObfuscated), WithWordlist([]string{"a", "b"})} {
+		if _, err := New("seed", WithVersion(Version1), WithLength(25), opt); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestGeneratorBounds(t *testing.T) {
+	at := time.Date(2026, 2, 3, 15, 4, 5, 0, time.UTC)
+	cases := []struct {
+		interval, period, next string
+	}{
+		{"second", "2026-02-03T15:04:05", "2026-02-03T15:04:06Z"},
+		{"minute", "2026-02-03T15:04", "2026-02-03T15:05:00Z"},
+		{"hour", "2026-02-03T15", "2026-02-03T16:00:00Z"},
+		{"day", "2026-02-03", "2026-02-04T00:00:00Z"},
+		{"month", "2026-02", "2026-03-01T00:00:00Z"},
+		{"quarter", "2026-Q1", "2026-04-01T00:00:00Z"},
+		{"year", "2026", "2027-01-01T00:00:00Z"},
+	}
+	for _, tc := range cases {
+		g, err := New("seed", WithInterval(tc.interval))
+		if err != nil {
+			t.Fatal(err)
+		}
+		period, next := g.Bounds(at)
+		if period != tc.period || next.Format(time.RFC3339) != tc.next {
+			t.Errorf("%s: got %q/%s, want %q/%s", tc.interval, period, next.Format(time.RFC3339), tc.period, tc.next)
+		}
+	}
+}
+
+func TestGeneratorLanguage(t *testing.T) {
+	g, err := New("seedphrase", WithLanguage(LanguageEnglish), WithSeparator("-"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The separator does not change the words or the hash.
+	if got := g.Derive("2026-02-03"); got.Value != "exotic-angry-answer" || got.Hash != "50011c26d0" {
+		t.Errorf("got %+v", got)
+	}
+	g, err = New("seedphrase", WithMode(ModeObfuscated), WithSeparator("-"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "trybeambold8" {
+		t.Errorf("obfuscated: got %q", got)
+	}
+
+	if _, err := New("seed", WithLanguage("klingon")); !errors.Is(err, ErrInvalidLanguage) {
+		t.Errorf("expected ErrInvalidLanguage, got %v", err)
+	}
+}
+
+func TestGeneratorLanguages(t *testing.T) {
+	// Every language picks the words at the same indices, with the same
+	// hash. Words are NFKD normalized.
+	want := map[string]string{
+		LanguageChineseSimplified:  "初家化",
+		LanguageChineseTraditional: "初家化",
+		LanguageCzech:              "konzervabizonblikat",
+		LanguageEnglish:            "exoticangryanswer",
+		LanguageFrench:             "élégantajouteralgue",
+		LanguageItalian:            "feltroalunnoambito",
+		LanguageJapanese:           "こすう\u3000いさん\u3000いずれ",
+		LanguageKorean:             "변신검사결과",
+		LanguageSpanish:            "espejoalfileralma",
+	}
+	if !slices.Equal(Languages(), slices.Sorted(maps.Keys(want))) {
+		t.Errorf("got languages %v", Languages())
+	}
+	for language, slug := range want {
+		g, err := New("seedphrase", WithLanguage(language))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// NFKD would turn the ideographic space into a space.
+		words := strings.Split(slug, "\u3000")
+		for i, w := range words {
+			words[i] = norm.NFKD.String(w)
+		}
+		if got := g.Derive("2026-02-03"); got.Value != strings.Join(words, "\u3000") || got.Hash != "50011c26d0" {
+			t.Errorf("%s: got %q/%s, want %q", language, got.Value, got.Hash, slug)
+		}
+	}
+
+	// Japanese words are joined by the ideographic space unless a
+	// separator is set.
+	g, err := New("seedphrase", WithLanguage(LanguageJapanese), WithSeparator("-"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != norm.NFKD.String("こすう-いさん-いずれ") {
+		t.Errorf("japanese with separator: got %q", got)
+	}
+	if DefaultSeparator("Japanese") != "\u3000" || DefaultSeparator(LanguageSpanish) != "" {
+		t.Errorf("got %q/%q", DefaultSeparator("Japanese"), DefaultSeparator(LanguageSpanish))
+	}
+}
+
+func TestGeneratorCase(t *testing.T) {
+	cases := map[string]string{
+		CaseLower: "exotic-angry-answer",
+		CaseTitle: "Exotic-Angry-Answer",
+		CaseCamel: "exotic-Angry-Answer",
+		CaseUpper: "EXOTIC-ANGRY-ANSWER",
+		"Title":   "Exotic-Angry-Answer",
+	}
+	for c, want := range cases {
+		g, err := New("seedphrase", WithCase(c), WithSeparator("-"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The case does not change the hash.
+		if got := g.Derive("2026-02-03"); got.Value != want || got.Hash != "50011c26d0" {
+			t.Errorf("%s: got %+v, want %q", c, got, want)
+		}
+	}
+
+	g, err := New("seedphrase", WithCase(CaseCamel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "exoticAngryAnswer" {
+		t.Errorf("camel: got %q", got)
+	}
+
+	// Custom words keep their case unless a case is set, which normalizes
+	// every word, the first one of camel case included.
+	words := []string{"Acme", "NASA", "red", "Blue"}
+	for c, want := range map[string]string{
+		"":        "NASANASAAcmeAcme",
+		CaseLower: "nasanasaacmeacme",
+		CaseTitle: "NasaNasaAcmeAcme",
+		CaseCamel: "nasaNasaAcmeAcme",
+	} {
+		opts := []Option{WithWordlist(words), WithLength(4)}
+		if c != "" {
+			opts = append(opts, WithCase(c))
+		}
+		g, err := New("seedphrase", opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.Derive("2026-02-03").Value; got != want {
+			t.Errorf("custom %q: got %q, want %q", c, got, want)
+		}
+	}
+	g, err = New("seedphrase", WithCase(CaseUpper), WithMode(ModeObfuscated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "trybeambold8" {
+		t.Errorf("obfuscated: got %q", got)
+	}
+	g, err = New("seedphrase", WithCase(CaseTitle), WithWordlist([]string{"éclair", "Brand", "blue", "gold"}), WithLength(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; got != "BrandBrandE\u0301clairE\u0301clair" {
+		t.Errorf("custom: got %q", got)
+	}
+
+	if _, err := New("seed", WithCase("snake")); !errors.Is(err, ErrInvalidCase) {
+		t.Errorf("expected ErrInvalidCase, got %v", err)
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	// Reference vectors: namespaces are the last argument of the ports.
+	cases := []struct {
+		namespace, mode string
+		length          int
+		slug, hash      string
+	}{
+		{"api", ModeBIP39, 3, "henlabeladmit", "346016ccde"},
+		{"docs", ModeBIP39, 3, "globefocustiger", "f8f65397ac"},
+		{"api", ModeObfuscated, 16, "cupclicklenliftl", "80df4899f17fb701"},
+		{"", ModeBIP39, 3, "mythmarriagevirus", "c2923898e3"},
+	}
+	for _, tc := range cases {
+		g, err := New("seedphrase", WithVersion(Version3), WithNamespace(tc.namespace), WithMode(tc.mode), WithLength(tc.length))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := g.Derive("2026-02-03")
+		if s.Value != tc.slug || s.Hash != tc.hash || s.Namespace != tc.namespace || g.Namespace() != tc.namespace {
+			t.Errorf("%q %s: got %+v, want %s/%s", tc.namespace, tc.mode, s, tc.slug, tc.hash)
+		}
+	}
+
+	for _, opts := range [][]Option{
+		{WithNamespace("api")},
+		{WithNamespace("api"), WithVersion(Version2)},
+		{WithNamespace("a\x00b"), WithVersion(Version3)},
+	} {
+		if _, err := New("seedphrase", opts...); !errors.Is(err, ErrInvalidNamespace) {
+			t.Errorf("expected ErrInvalidNamespace, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package timeslug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// interval is a rotation interval: a single unit, a multiple of a unit
+// counted from an epoch, or a number of calendar months.
+type interval struct {
+	duration time.Duration
+	format   string
+	months   int
+	// iso formats week periods as ISO 8601 weeks (2026-W06) starting on
+	// Monday instead of the legacy day-of-month layout.
+	iso bool
+	// name is set for multiples such as 15m or 6h, whose periods are
+	// buckets of duration counted from epoch.
+	name  string
+	epoch time.Time
+	// zone appends the UTC offset to sub-day periods outside UTC, so the
+	// hour repeated when daylight saving time ends is a separate period.
+	zone bool
+}
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// units are the suffixes of multiples, largest first.
+var units = []struct {
+	suffix   string
+	duration time.Duration
+}{
+	{"w", week},
+	{"d", day},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+func parseInterval(s string) (interval, error) {
+	switch strings.ToLower(s) {
+	case "s", "second", "seconds":
+		return interval{duration: time.Second, format: "2006-01-02T15:04:05"}, nil
+	case "m", "minute", "minutes":
+		return interval{duration: time.Minute, format: "2006-01-02T15:04"}, nil
+	case "h", "hour", "hours":
+		return interval{duration: time.Hour, format: "2006-01-02T15"}, nil
+	case "", "d", "day", "days":
+		return interval{duration: day, format: "2006-01-02"}, nil
+	case "w", "week", "weeks":
+		return interval{duration: week, format: "2006-W02"}, nil
+	case "mo", "month", "months":
+		return interval{months: 1}, nil
+	case "q", "quarter", "quarters":
+		return interval{months: 3}, nil
+	case "y", "year", "years":
+		return interval{months: 12}, nil
+	}
+
+	d, err := parseMultiple(s)
+	if err == nil && d > 0 {
+		for _, u := range units {
+			switch {
+			case d == u.duration && d < week:
+				// A single unit keeps the periods of its named interval.
+				// Legacy week periods change with the day of the month, so
+				// 1w and 7d are true multiples instead.
+				return parseInterval(u.suffix)
+			case d%u.duration == 0:
+				return interval{duration: d, name: fmt.Sprintf("%d%s", d/u.duration, u.suffix)}, nil
+			}
+		}
+	}
+	return interval{}, fmt.Errorf("%w: %s", ErrInvalidInterval, s)
+}
+
+// parseMultiple parses a Go duration such as 15m or 1h30m, or a number of
+// days or weeks such as 3d or 2w.
+func parseMultiple(s string) (time.Duration, error) {
+	for _, u := range units[:2] {
+		if n, ok := strings.CutSuffix(s, u.suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(count) * u.duration, nil
+		}
+	}
+	return time.ParseDuration(s)
+}
+
+// withEpoch counts the buckets of a multiple from epoch. Bucket starts are
+// formatted at the coarsest unit that both the duration and the epoch are
+// aligned to.
+func (iv interval) withEpoch(epoch time.Time) interval {
+	if iv.name == "" {
+		return iv
+	}
+	iv.epoch = epoch.UTC().Truncate(time.Second)
+	aligned := func(unit time.Duration) bool {
+		return iv.duration%unit == 0 && iv.epoch.Unix()%int64(unit/time.Second) == 0
+	}
+	switch {
+	case aligned(day):
+		iv.format = "2006-01-02"
+	case aligned(time.Hour):
+		iv.format = "2006-01-02T15"
+	case aligned(time.Minute):
+		iv.format = "2006-01-02T15:04"
+	default:
+		iv.format = "2006-01-02T15:04:05"
+	}
+	return iv
+}
+
+// start returns the beginning of the bucket of a multiple containing t. It
+// counts in Unix seconds like the reference implementations: a
+// time.Duration saturates 292 years from the epoch.
+func (iv interval) start(t time.Time) time.Time {
+	d, size := t.Unix()-iv.epoch.Unix(), int64(iv.duration/time.Second)
+	n := d / size
+	if d%size < 0 {
+		n--
+	}
+	return time.Unix(iv.epoch.Unix()+n*size, 0).UTC()
+}
+
+// period formats the period containing t: 2026-02 for months, 2026-Q1 for
+// quarters, 2026 for years and 2026-02-03T12:15/15m for multiples.
+func (iv interval) period(t time.Time) string {
+	if iv.name != "" {
+		return iv.start(t).Format(iv.format) + "/" + iv.name
+	}
+	if iv.iso {
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	}
+	switch iv.months {
+	case 0:
+		if iv.zone && iv.duration < day {
+			return t.Format(iv.format + "-07:00")
+		}
+		return t.Format(iv.format)
+	case 1:
+		return t.Format("2006-01")
+	case 3:
+		return fmt.Sprintf("%d-Q%d", t.Year(), (t.Month()-1)/3+1)
+	}
+	return t.Format("2006")
+}
+
+// add steps t by n intervals. Calendar intervals step from the first day of
+// the period, so months of different lengths never skip or repeat a period.
+// Days and weeks step by calendar days, which are not always 24 hours long
+// outside UTC.
+func (iv interval) add(t time.Time, n int) time.Time {
+	if iv.months == 0 {
+		if iv.name == "" && iv.duration >= day {
+			return t.AddDate(0, 0, n*int(iv.duration/day))
+		}
+		return t.Add(time.Duration(n) * iv.duration)
+	}
+	month := (int(t.Month())-1)/iv.months*iv.months + 1
+	start := time.Date(t.Year(), time.Month(month), 1, 0, 0, 0, 0, t.Location())
+	return start.AddDate(0, n*iv.months, 0)
+}
+
+// begin returns the instant the period containing t begins.
+func (iv interval) begin(t time.Time) time.Time {
+	switch {
+	case iv.months > 0:
+		return iv.add(t, 0)
+	case iv.name != "":
+		return iv.start(t)
+	case iv.iso:
+		return time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case iv.duration >= day:
+		// Week periods are formatted by day, so they change at every midnight.
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	_, offset := t.Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(iv.duration).Add(-shift)
+}
+
+// next returns the instant the period after the one containing t begins.
+func (iv interval) next(t time.Time) time.Time {
+	if iv.months > 0 {
+		return iv.add(t, 1)
+	}
+	if iv.name != "" {
+		return iv.start(t).Add(iv.duration)
+	}
+	if iv.iso {
+		monday := time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+		return monday.AddDate(0, 0, 7)
+	}
+	if iv.duration >= day {
+		// Week periods are formatted by day, so they change at every midnight.
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	}
+	// Truncate the wall-clock time, so periods start on local units even
+	// in zones with fractional-hour offsets.
+	_, offset := t.Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(iv.duration).Add(iv.duration - shift)
+}
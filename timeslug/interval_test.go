@@ -0,0 +1,273 @@
+package timeslug
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	valid := []string{"s", "second", "m", "minute", "h", "hour", "d", "day", "", "w", "week", "mo", "month", "q", "quarter", "y", "year", "15m", "6h", "36h", "90s", "1h30m", "3d", "2w"}
+	for _, s := range valid {
+		if _, err := parseInterval(s); err != nil {
+			t.Errorf("parseInterval(%q) failed: %v", s, err)
+		}
+	}
+	for _, s := range []string{"invalid", "0m", "-6h", "1500ms", "xw"} {
+		if _, err := parseInterval(s); err == nil {
+			t.Errorf("parseInterval(%q) should fail", s)
+		}
+	}
+}
+
+func TestCalendarPeriods(t *testing.T) {
+	cases := []struct {
+		interval string
+		anchor   time.Time
+		want     []string
+	}{
+		// Stepping from Jan 31 must not skip February
+		{"month", time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC), []string{"2025-12", "2026-01", "2026-02"}},
+		{"month", time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), []string{"2026-02", "2026-03", "2026-04"}},
+		{"quarter", time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC), []string{"2025-Q4", "2026-Q1", "2026-Q2"}},
+		{"quarter", time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), []string{"2026-Q3", "2026-Q4", "2027-Q1"}},
+		{"year", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), []string{"2023", "2024", "2025"}},
+	}
+	for _, tc := range cases {
+		g, err := New("seed", WithInterval(tc.interval))
+		if err != nil {
+			t.Fatal(err)
+		}
+		slugs, err := g.Window(tc.anchor, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, s := range slugs {
+			if s.Period != tc.want[i] {
+				t.Errorf("%s %s: slugs[%d].Period = %q, want %q", tc.interval, tc.anchor.Format(time.DateOnly), i, s.Period, tc.want[i])
+			}
+		}
+	}
+}
+
+func TestISOWeekPeriods(t *testing.T) {
+	cases := []struct {
+		at     time.Time
+		period string
+		next   string
+	}{
+		{time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), "2026-W06", "2026-02-09T00:00:00Z"},
+		{time.Date(2026, 2, 8, 23, 59, 59, 0, time.UTC), "2026-W06", "2026-02-09T00:00:00Z"},
+		{time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC), "2026-W07", "2026-02-16T00:00:00Z"},
+		// ISO years do not follow calendar years.
+		{time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC), "2026-W53", "2027-01-04T00:00:00Z"},
+		{time.Date(2024, 12, 30, 12, 0, 0, 0, time.UTC), "2025-W01", "2025-01-06T00:00:00Z"},
+	}
+	g, err := New("seed", WithInterval("week"), WithISOWeek(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range cases {
+		period, next := g.Bounds(tc.at)
+		if period != tc.period || next.Format(time.RFC3339) != tc.next {
+			t.Errorf("%s: got %q/%s, want %q/%s", tc.at, period, next.Format(time.RFC3339), tc.period, tc.next)
+		}
+	}
+
+	// The flag only affects the week interval.
+	g, err = New("seed", WithISOWeek(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period, _ := g.Bounds(cases[0].at); period != "2026-02-02" {
+		t.Errorf("day interval with ISO weeks: got %q", period)
+	}
+}
+
+func TestMultiplePeriods(t *testing.T) {
+	at := time.Date(2026, 2, 3, 12, 20, 0, 0, time.UTC)
+	cases := []struct {
+		interval string
+		epoch    time.Time
+		period   string
+		next     string
+	}{
+		{"15m", time.Unix(0, 0), "2026-02-03T12:15/15m", "2026-02-03T12:30:00Z"},
+		{"6h", time.Unix(0, 0), "2026-02-03T12/6h", "2026-02-03T18:00:00Z"},
+		{"36h", time.Unix(0, 0), "2026-02-03T00/36h", "2026-02-04T12:00:00Z"},
+		{"90s", time.Unix(0, 0), "2026-02-03T12:19:30/90s", "2026-02-03T12:21:00Z"},
+		{"1h30m", time.Unix(0, 0), "2026-02-03T12:00/90m", "2026-02-03T13:30:00Z"},
+		// The Unix epoch is a Thursday.
+		{"2w", time.Unix(0, 0), "2026-01-29/2w", "2026-02-12T00:00:00Z"},
+		{"14d", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "2026-01-26/2w", "2026-02-09T00:00:00Z"},
+		{"7d", time.Unix(0, 0), "2026-01-29/1w", "2026-02-05T00:00:00Z"},
+		{"1w", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "2026-02-02/1w", "2026-02-09T00:00:00Z"},
+		// The epoch sets the resolution of the period.
+		{"6h", time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC), "2026-02-03T06:30/6h", "2026-02-03T12:30:00Z"},
+		// Times before the epoch floor to earlier buckets.
+		{"15m", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), "2026-02-03T12:15/15m", "2026-02-03T12:30:00Z"},
+	}
+	for _, tc := range cases {
+		g, err := New("seed", WithInterval(tc.interval), WithEpoch(tc.epoch))
+		if err != nil {
+			t.Fatal(err)
+		}
+		period, next := g.Bounds(at)
+		if period != tc.period || next.Format(time.RFC3339) != tc.next {
+			t.Errorf("%s from %s: got %q/%s, want %q/%s", tc.interval, tc.epoch.UTC(), period, next.Format(time.RFC3339), tc.period, tc.next)
+		}
+	}
+
+	// Buckets far from the epoch do not saturate, as in the reference
+	// implementations.
+	slugs, err := Generate("seed", "9999-12-31T23:00:00Z", 3, 3, "15m", "bip39")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"9999-12-31T22:45/15m", "9999-12-31T23:00/15m", "9999-12-31T23:15/15m"} {
+		if slugs[i].Period != want {
+			t.Errorf("year 9999: got %q, want %q", slugs[i].Period, want)
+		}
+	}
+	g, err := New("seed", WithInterval("6h"), WithEpoch(time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period, _ := g.Bounds(time.Date(1, 1, 1, 12, 0, 0, 0, time.UTC)); period != "0001-01-01T06:30/6h" {
+		t.Errorf("year 1: got %q", period)
+	}
+
+	// A single unit keeps the legacy period format.
+	g, err = New("seed", WithInterval("1h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period, _ := g.Bounds(at); period != "2026-02-03T12" {
+		t.Errorf("1h: got %q", period)
+	}
+
+	// A 7d period lasts seven days, unlike the legacy week period.
+	g, err = New("seed", WithInterval("7d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	period, next := g.Bounds(at)
+	for day := at; day.Before(next); day = day.AddDate(0, 0, 1) {
+		if p, _ := g.Bounds(day); p != period {
+			t.Errorf("7d: period changed from %q to %q on %s", period, p, day.Format(time.DateOnly))
+		}
+	}
+	if start := g.iv.begin(at); next.Sub(start) != week {
+		t.Errorf("7d: period from %s to %s", start, next)
+	}
+}
+
+func TestZonePeriods(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	cases := []struct {
+		interval string
+		anchor   time.Time
+		want     []string
+		next     string
+	}{
+		// Daylight saving time ends at 02:00 EDT on 2026-11-01: the
+		// repeated hour is a separate period.
+		{"hour", time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC), []string{"2026-11-01T00-04:00", "2026-11-01T01-04:00", "2026-11-01T01-05:00"}, "2026-11-01T01:00:00-05:00"},
+		// It starts at 02:00 EST on 2026-03-08: the skipped hour has no period.
+		{"hour", time.Date(2026, 3, 8, 6, 30, 0, 0, time.UTC), []string{"2026-03-08T00-05:00", "2026-03-08T01-05:00", "2026-03-08T03-04:00"}, "2026-03-08T03:00:00-04:00"},
+		// The 25 hour day is a single period.
+		{"day", time.Date(2026, 11, 1, 4, 30, 0, 0, time.UTC), []string{"2026-10-31", "2026-11-01", "2026-11-02"}, "2026-11-02T00:00:00-05:00"},
+	}
+	for _, tc := range cases {
+		g, err := New("seed", WithInterval(tc.interval), WithLocation(ny))
+		if err != nil {
+			t.Fatal(err)
+		}
+		slugs, err := g.Window(tc.anchor, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, s := range slugs {
+			if s.Period != tc.want[i] {
+				t.Errorf("%s %s: slugs[%d].Period = %q, want %q", tc.interval, tc.anchor, i, s.Period, tc.want[i])
+			}
+		}
+		if _, next := g.Bounds(tc.anchor); next.Format(time.RFC3339) != tc.next {
+			t.Errorf("%s %s: next = %s, want %s", tc.interval, tc.anchor, next.Format(time.RFC3339), tc.next)
+		}
+	}
+}
+
+func TestUTCPeriods(t *testing.T) {
+	// UTC under another name keeps the periods of the default time zone.
+	at := time.Date(2026, 2, 3, 5, 0, 0, 0, time.UTC)
+	for _, name := range []string{"UTC", "Etc/UTC", "Etc/GMT"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Skip(err)
+		}
+		g, err := New("seed", WithInterval("hour"), WithLocation(loc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if period, _ := g.Bounds(at); period != "2026-02-03T05" {
+			t.Errorf("%s: got %q", name, period)
+		}
+	}
+
+	// A fixed offset, or a zone at UTC only part of the year, is not UTC.
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, loc := range []*time.Location{time.FixedZone("UTC+1", 3600), london} {
+		if isUTC(loc) {
+			t.Errorf("%s should not be UTC", loc)
+		}
+	}
+}
+
+func TestFractionalOffsetNext(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skip(err)
+	}
+	g, err := New("seed", WithInterval("hour"), WithLocation(kolkata))
+	if err != nil {
+		t.Fatal(err)
+	}
+	period, next := g.Bounds(time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC))
+	if period != "2026-02-03T17+05:30" || next.Format(time.RFC3339) != "2026-02-03T18:00:00+05:30" {
+		t.Errorf("got %q/%s", period, next.Format(time.RFC3339))
+	}
+}
+
+func TestBegin(t *testing.T) {
+	at := time.Date(2026, 2, 4, 13, 47, 12, 0, time.UTC)
+	cases := []struct {
+		interval string
+		isoWeek  bool
+		want     time.Time
+	}{
+		{"15m", false, time.Date(2026, 2, 4, 13, 45, 0, 0, time.UTC)},
+		{"hour", false, time.Date(2026, 2, 4, 13, 0, 0, 0, time.UTC)},
+		{"day", false, time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{"week", true, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"month", false, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"quarter", false, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		g, err := New("seed", WithInterval(tc.interval), WithISOWeek(tc.isoWeek))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.iv.begin(at); !got.Equal(tc.want) {
+			t.Errorf("%s: begin = %s, want %s", tc.interval, got, tc.want)
+		}
+		if next := g.iv.next(at); !g.iv.begin(next).Equal(next) {
+			t.Errorf("%s: begin(next) = %s, want %s", tc.interval, g.iv.begin(next), next)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package timeslug
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var ErrInvalidSeeds = errors.New("invalid seeds")
+
+// Seed is one of the scheduled seeds of a Generator. It derives the slugs of
+// periods beginning at or after ValidFrom and before ValidUntil; a zero time
+// leaves that end open. While the ranges of two seeds overlap, the seed with
+// the later ValidFrom derives new slugs and slugs of both seeds verify, so
+// a seed can be rotated without invalidating slugs handed out before.
+type Seed struct {
+	ID         string
+	Value      string
+	ValidFrom  time.Time
+	ValidUntil time.Time
+}
+
+// WithSeeds replaces the seed passed to New with scheduled seeds. Together
+// the seeds must cover all time: the earliest has no ValidFrom, the latest
+// no ValidUntil, and every ValidFrom lies within the range of an earlier
+// seed.
+func WithSeeds(seeds ...Seed) Option {
+	return func(g *Generator) { g.seeds = slices.Clone(seeds) }
+}
+
+// checkSeeds sorts seeds by ValidFrom and checks that their ranges leave no
+// gap.
+func checkSeeds(seeds []Seed) error {
+	if len(seeds) == 0 {
+		return fmt.Errorf("%w: no seeds", ErrInvalidSeeds)
+	}
+	ids := map[string]bool{}
+	for _, s := range seeds {
+		switch {
+		case s.ID == "":
+			return fmt.Errorf("%w: empty id", ErrInvalidSeeds)
+		case ids[s.ID]:
+			return fmt.Errorf("%w: duplicate id %q", ErrInvalidSeeds, s.ID)
+		case s.Value == "":
+			return fmt.Errorf("%w: seed %q is empty", ErrInvalidSeeds, s.ID)
+		case !s.ValidUntil.IsZero() && !s.ValidFrom.Before(s.ValidUntil):
+			return fmt.Errorf("%w: seed %q is valid until before it is valid from", ErrInvalidSeeds, s.ID)
+		}
+		ids[s.ID] = true
+	}
+
+	slices.SortStableFunc(seeds, func(a, b Seed) int { return a.ValidFrom.Compare(b.ValidFrom) })
+	if !seeds[0].ValidFrom.IsZero() {
+		return fmt.Errorf("%w: no seed before %s", ErrInvalidSeeds, seeds[0].ValidFrom.Format(time.RFC3339))
+	}
+	// until is the end of the time covered so far, zero once it is open.
+	until := seeds[0].ValidUntil
+	for _, s := range seeds[1:] {
+		if !until.IsZero() && s.ValidFrom.After(until) {
+			return fmt.Errorf("%w: no seed from %s to %s", ErrInvalidSeeds, until.Format(time.RFC3339), s.ValidFrom.Format(time.RFC3339))
+		}
+		if s.ValidUntil.IsZero() || (!until.IsZero() && s.ValidUntil.After(until)) {
+			until = s.ValidUntil
+		}
+	}
+	if !until.IsZero() {
+		return fmt.Errorf("%w: no seed after %s", ErrInvalidSeeds, until.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// activeSeeds returns the seeds valid for a period beginning at begin, the
+// one deriving new slugs first.
+func (g *Generator) activeSeeds(begin time.Time) []Seed {
+	var active []Seed
+	for _, s := range g.seeds {
+		if (s.ValidFrom.IsZero() || !begin.Before(s.ValidFrom)) && (s.ValidUntil.IsZero() || begin.Before(s.ValidUntil)) {
+			active = append(active, s)
+		}
+	}
+	// g.seeds is sorted by ValidFrom.
+	slices.Reverse(active)
+	return active
+}
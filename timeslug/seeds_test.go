@@ -0,0 +1,127 @@
+package timeslug
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSeedRotation(t *testing.T) {
+	from := time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC)
+	// Given out of order: New sorts them by ValidFrom.
+	g, err := New("", WithSeeds(
+		Seed{ID: "new", Value: "other", ValidFrom: from},
+		Seed{ID: "old", Value: "seedphrase", ValidUntil: until},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, _ := New("seedphrase")
+	other, _ := New("other")
+
+	cases := []struct {
+		at   time.Time
+		want Slug
+		id   string
+	}{
+		{from.Add(-time.Hour), old.At(from.Add(-time.Hour)), "old"},
+		{from, other.At(from), "new"},
+		{until, other.At(until), "new"},
+	}
+	for _, tc := range cases {
+		got := g.At(tc.at)
+		if got.Value != tc.want.Value || got.SeedID != tc.id {
+			t.Errorf("At(%s) = %+v, want %s from %s", tc.at, got, tc.want.Value, tc.id)
+		}
+	}
+	if got := g.Derive("2026-01-01"); got.Value != other.Derive("2026-01-01").Value || got.SeedID != "new" {
+		t.Errorf("Derive used %q, want the latest seed", got.SeedID)
+	}
+
+	// While both are valid, slugs of the old seed still verify.
+	m, err := g.Verify(old.At(from).Value, from, 0)
+	if err != nil || m.SeedID != "old" {
+		t.Errorf("old seed during overlap: %+v, %v", m, err)
+	}
+	m, err = g.Verify(other.At(from).Value, from, 0)
+	if err != nil || m.SeedID != "new" {
+		t.Errorf("new seed during overlap: %+v, %v", m, err)
+	}
+	if _, err := g.Verify(old.At(until).Value, until, 0); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("old seed after overlap: expected ErrNoMatch, got %v", err)
+	}
+	// The tolerance period before until is still within the overlap.
+	if m, err := g.Verify(old.At(until.Add(-time.Hour)).Value, until, 1); err != nil || m.SeedID != "old" || m.Offset != -1 {
+		t.Errorf("old seed in tolerance: %+v, %v", m, err)
+	}
+}
+
+func TestSeedsBeforeYearOne(t *testing.T) {
+	// A zero ValidFrom is open, not the instant 0001-01-01.
+	anchor := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	single, _ := New("seedphrase")
+	seeded, err := New("", WithSeeds(
+		Seed{ID: "old", Value: "seedphrase", ValidUntil: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Seed{ID: "new", Value: "other", ValidFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, g := range map[string]*Generator{"single": single, "seeds": seeded} {
+		slugs, err := g.Window(anchor, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slugs[0].Period != "0000-12-31" || slugs[1].Period != "0001-01-01" {
+			t.Errorf("%s: got periods %s, %s", name, slugs[0].Period, slugs[1].Period)
+		}
+		for _, s := range slugs {
+			if want := single.Derive(s.Period).Value; s.Value != want {
+				t.Errorf("%s %s: got %q, want %q", name, s.Period, s.Value, want)
+			}
+		}
+		if m, err := g.Verify(slugs[0].Value, anchor, 1); err != nil || m.Offset != -1 {
+			t.Errorf("%s: verify %+v, %v", name, m, err)
+		}
+	}
+}
+
+func TestCheckSeeds(t *testing.T) {
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		seeds []Seed
+	}{
+		{"none", []Seed{}},
+		{"empty id", []Seed{{Value: "a"}}},
+		{"duplicate id", []Seed{{ID: "a", Value: "a", ValidUntil: t1}, {ID: "a", Value: "b", ValidFrom: t1}}},
+		{"empty value", []Seed{{ID: "a"}}},
+		{"inverted", []Seed{{ID: "a", Value: "a", ValidFrom: t2, ValidUntil: t1}}},
+		{"no start", []Seed{{ID: "a", Value: "a", ValidFrom: t1}}},
+		{"no end", []Seed{{ID: "a", Value: "a", ValidUntil: t1}}},
+		{"gap", []Seed{{ID: "a", Value: "a", ValidUntil: t1}, {ID: "b", Value: "b", ValidFrom: t2}}},
+	}
+	for _, tc := range cases {
+		if _, err := New("", WithSeeds(tc.seeds...)); !errors.Is(err, ErrInvalidSeeds) {
+			t.Errorf("%s: expected ErrInvalidSeeds, got %v", tc.name, err)
+		}
+	}
+
+	// Adjacent ranges and a seed within another leave no gap.
+	if _, err := New("", WithSeeds(
+		Seed{ID: "a", Value: "a", ValidUntil: t1},
+		Seed{ID: "b", Value: "b", ValidFrom: t1},
+		Seed{ID: "c", Value: "c", ValidFrom: t1, ValidUntil: t2},
+	)); err != nil {
+		t.Errorf("adjacent: %v", err)
+	}
+}
+
+func TestSingleSeed(t *testing.T) {
+	g, _ := New("seedphrase")
+	if s := g.At(time.Now()); s.SeedID != "" {
+		t.Errorf("SeedID = %q, want empty", s.SeedID)
+	}
+}
@@ -1,66 +1,54 @@
-package provider
+package timeslug
 
 import (
 	"crypto/hmac"
 	"crypto/sha256"
-	_ "embed"
-	"encoding/hex"
 	"fmt"
 	"slices"
 	"strings"
 	"time"
 )
 
-//go:embed bip39_english.txt
-var bip39Raw string
-var bip39Words []string
-
-func init() {
-	bip39Words = strings.Split(strings.TrimSpace(bip39Raw), "\n")
-}
-
+// Slug is a generated slug with the period it belongs to and its
+// verification hash.
 type Slug struct {
 	Value  string
 	Period string
 	Hash   string
+	// SeedID is the ID of the scheduled seed the slug was derived with, or
+	// empty without WithSeeds.
+	SeedID string
+	// Namespace is the namespace set with WithNamespace.
+	Namespace string
 }
 
 // Generate creates slugs for a time window centered on anchor.
 func Generate(seed, anchor string, length, window int, interval, mode string) ([]Slug, error) {
-	anchorTime, err := parseTime(anchor)
+	anchorTime, err := ParseTime(anchor)
 	if err != nil {
 		return nil, err
 	}
-	duration, format, err := parseInterval(interval)
+	g, err := New(seed, WithLength(length), WithInterval(interval), WithMode(mode))
 	if err != nil {
 		return nil, err
 	}
-
-	slugs := make([]Slug, window)
-	start := -window / 2
-	for i := range slugs {
-		period := anchorTime.Add(time.Duration(start+i) * duration).Format(format)
-		value, hash := derive(seed, period, length, mode)
-		slugs[i] = Slug{Value: value, Period: period, Hash: hash}
-	}
-	return slugs, nil
+	return g.Window(anchorTime, window)
 }
 
-func derive(seed, period string, length int, mode string) (string, string) {
-	entropy := hmacSHA256(seed, seed+":"+period)
-
-	if strings.EqualFold(mode, "obfuscated") {
-		slug := buildObfuscatedSlug(entropy)
-		hash := hmacSHA256(seed, seed+":skid:"+period)
-		hashLen := min((length+1)/2, 16)
-		return slug, hex.EncodeToString(hash[:hashLen])
+// Derive creates the slug for a single period string with Version1. It
+// checks length and mode like Generate.
+func Derive(seed, period string, length int, mode string) (Slug, error) {
+	g, err := New(seed, WithLength(length), WithMode(mode), WithVersion(Version1))
+	if err != nil {
+		return Slug{}, err
 	}
+	return g.Derive(period), nil
+}
 
-	// BIP39 mode: concatenate mnemonic words
-	words := entropyToBIP39Words(entropy)
-	wordCount := min(length, 24)
-	hashLen := (wordCount*11 + 7) / 8
-	return strings.Join(words[:wordCount], ""), hex.EncodeToString(entropy[:hashLen])
+// derive creates the slug value and hash of a period with a registered
+// algorithm version.
+func derive(seed, period string, length int, mode string, version int) (string, string) {
+	return algorithms[version].derive(seed, period, params{length: length, mode: mode, words: wordlists[LanguageEnglish]})
 }
 
 func hmacSHA256(key, message string) []byte {
@@ -69,7 +57,43 @@ func hmacSHA256(key, message string) []byte {
 	return h.Sum(nil)
 }
 
-func entropyToBIP39Words(entropy []byte) []string {
+// entropyStream extends the 32 bytes of entropy for a period with further
+// HMAC blocks: block i is HMAC(seed, block i-1 || seed:period || i), so the
+// first block is the entropy every version derives from.
+type entropyStream struct {
+	key, info []byte
+	blocks    [][]byte
+	// offset is added to the counter byte of each block: 1 for HKDF, whose
+	// counter starts at 1 with the first block.
+	offset int
+}
+
+func newEntropyStream(seed, period string, entropy []byte) *entropyStream {
+	return &entropyStream{key: []byte(seed), info: []byte(seed + ":" + period), blocks: [][]byte{entropy}}
+}
+
+// newHKDFStream returns the output of HKDF-Expand (RFC 5869) with a
+// pseudorandom key and info in 32 byte blocks.
+func newHKDFStream(prk, info []byte) *entropyStream {
+	h := hmac.New(sha256.New, prk)
+	h.Write(info)
+	h.Write([]byte{1})
+	return &entropyStream{key: prk, info: info, blocks: [][]byte{h.Sum(nil)}, offset: 1}
+}
+
+// block returns block i, computing the blocks before it as needed.
+func (e *entropyStream) block(i int) []byte {
+	for len(e.blocks) <= i {
+		h := hmac.New(sha256.New, e.key)
+		h.Write(e.blocks[len(e.blocks)-1])
+		h.Write(e.info)
+		h.Write([]byte{byte(len(e.blocks) + e.offset)})
+		e.blocks = append(e.blocks, h.Sum(nil))
+	}
+	return e.blocks[i]
+}
+
+func entropyToBIP39Words(entropy []byte, wordlist []string) []string {
 	// Standard BIP39: 256 bits entropy + 8 bits checksum = 264 bits = 24 words
 	checksum := sha256.Sum256(entropy)
 	allBits := slices.Concat(bytesToBits(entropy), bytesToBits(checksum[:1]))
@@ -77,7 +101,7 @@ func entropyToBIP39Words(entropy []byte) []string {
 	words := make([]string, 24)
 	for i := range words {
 		index := bitsToInt(allBits[i*11 : i*11+11])
-		words[i] = bip39Words[index]
+		words[i] = wordlist[index]
 	}
 	return words
 }
@@ -174,10 +198,59 @@ func containsBlockedWord(s string) bool {
 	return false
 }
 
-// buildObfuscatedSlug creates a startup-style name like "trybeambold8"
-// Structure: [prefix] + word1 + [mid] + word2 + ending
+// buildObfuscatedSlug creates a startup-style name like "trybeambold8" of
+// 10 to 18 characters.
 func buildObfuscatedSlug(entropy []byte) string {
 	const minLen, maxLen = 10, 18
+	result := obfuscatedCore(entropy)
+	result = padToMinLength(result, minLen, entropy)
+	result = removeBlockedWords(result, entropy)
+	result = truncateToMaxLength(result, minLen, maxLen)
+	result = removeTripleLetters(result)
+	return result
+}
+
+// buildObfuscatedSlugLength creates a startup-style name of exactly length
+// characters. It starts from the same layers as buildObfuscatedSlug and
+// appends a word and a syllable from each further entropy block until the
+// slug is long enough.
+func buildObfuscatedSlugLength(e *entropyStream, length int) string {
+	// Blocked words and triple letters can only form near the end, so all
+	// but the last activeTail characters are settled and never rescanned.
+	const activeTail = 32
+	var done strings.Builder
+	done.Grow(length)
+	s := obfuscatedCore(e.block(0))
+	for i := 0; ; i++ {
+		s = removeTripleLetters(removeBlockedWords(s, e.block(i)))
+		if n := length - done.Len(); len(s) >= n {
+			if s[n-1] != '-' {
+				done.WriteString(s[:n])
+				return done.String()
+			}
+			// A slug must not end with the dash of a mid element. The
+			// replacement is filtered like any other chunk.
+			c, _ := pick(e.block(0), 31, consonants)
+			s = s[:n-1] + c
+			continue
+		}
+		if settled := len(s) - activeTail; settled > 0 {
+			done.WriteString(s[:settled])
+			s = s[settled:]
+		}
+		entropy := e.block(i + 1)
+		word, offset := pick(entropy, 0, techWords)
+		if entropy[offset]%5 == 0 {
+			word = shortenWord(word)
+		}
+		syl, _ := makeSyllable(entropy, offset+1)
+		s += word + syl
+	}
+}
+
+// obfuscatedCore creates the layers of an obfuscated slug.
+// Structure: [prefix] + word1 + [mid] + word2 + ending
+func obfuscatedCore(entropy []byte) string {
 	offset := 0
 	var slug strings.Builder
 
@@ -243,12 +316,7 @@ func buildObfuscatedSlug(entropy []byte) string {
 		slug.WriteString(suf)
 	}
 
-	result := slug.String()
-	result = padToMinLength(result, minLen, entropy)
-	result = removeBlockedWords(result, entropy)
-	result = truncateToMaxLength(result, minLen, maxLen)
-	result = removeTripleLetters(result)
-	return result
+	return slug.String()
 }
 
 func padToMinLength(s string, minLen int, entropy []byte) string {
@@ -307,27 +375,19 @@ var timeFormats = []string{
 	"2006-01-02",
 }
 
-func parseTime(s string) (time.Time, error) {
+// ParseTime parses an anchor in any of the supported formats: RFC3339 or
+// 2006-01-02 optionally followed by T15, T15:04 or T15:04:05.
+func ParseTime(s string) (time.Time, error) {
+	return ParseTimeIn(s, time.UTC)
+}
+
+// ParseTimeIn is like ParseTime but interprets times without an offset as
+// wall-clock times in loc.
+func ParseTimeIn(s string, loc *time.Location) (time.Time, error) {
 	for _, format := range timeFormats {
-		if t, err := time.Parse(format, s); err == nil {
+		if t, err := time.ParseInLocation(format, s, loc); err == nil {
 			return t, nil
 		}
 	}
-	return time.Time{}, fmt.Errorf("invalid time: %s", s)
-}
-
-func parseInterval(s string) (time.Duration, string, error) {
-	switch strings.ToLower(s) {
-	case "s", "second", "seconds":
-		return time.Second, "2006-01-02T15:04:05", nil
-	case "m", "minute", "minutes":
-		return time.Minute, "2006-01-02T15:04", nil
-	case "h", "hour", "hours":
-		return time.Hour, "2006-01-02T15", nil
-	case "", "d", "day", "days":
-		return 24 * time.Hour, "2006-01-02", nil
-	case "w", "week", "weeks":
-		return 7 * 24 * time.Hour, "2006-W02", nil
-	}
-	return 0, "", fmt.Errorf("invalid interval: %s", s)
+	return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidTime, s)
 }
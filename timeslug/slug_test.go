@@ -1,7 +1,9 @@
-package provider
+package timeslug
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -16,24 +18,49 @@ var testVectors = []struct {
 	{"seedphrase", "2026-02-05", "obfuscated", 16, "trycorefastfum", "8bb68bd056e4a6ff"},
 	{"seedphrase", "2026-02-03", "bip39", 3, "exoticangryanswer", "50011c26d0"},
 	{"seedphrase", "2026-02-03", "bip39", 5, "exoticangryanswerpatternmain", "50011c26d0a864"},
+	{"seedphrase", "2026-02", "bip39", 3, "glasspinkcrowd", "62f4a0d16d"},
+	{"seedphrase", "2026-Q1", "obfuscated", 16, "proboxfast101", "1481bf062f65f0e5"},
+	{"seedphrase", "2026", "bip39", 3, "skillsquarepupil", "ca7a6eb7c4"},
+	{"seedphrase", "2026-W06", "obfuscated", 16, "ivoryclearvilor", "4405d1bdd73c52c7"},
+	{"seedphrase", "2026-02-03T12:15/15m", "obfuscated", 16, "pen-dotpax", "f652cdf8dc97eaf5"},
 }
 
 func TestDerive(t *testing.T) {
 	for _, tc := range testVectors {
-		slug, hash := derive(tc.seed, tc.period, tc.length, tc.mode)
+		slug, hash := derive(tc.seed, tc.period, tc.length, tc.mode, Version1)
 		if slug != tc.slug || hash != tc.hash {
 			t.Errorf("%s/%s: got %q/%q, want %q/%q", tc.period, tc.mode, slug, hash, tc.slug, tc.hash)
 		}
 	}
 
 	// Mode is case-insensitive
-	s1, _ := derive("seed", "2026-01-01", 3, "bip39")
-	s2, _ := derive("seed", "2026-01-01", 3, "BIP39")
+	s1, _ := derive("seed", "2026-01-01", 3, "bip39", Version1)
+	s2, _ := derive("seed", "2026-01-01", 3, "BIP39", Version1)
 	if s1 != s2 {
 		t.Error("mode should be case insensitive")
 	}
 }
 
+func TestDerivePublic(t *testing.T) {
+	s, err := Derive("seedphrase", "2026-02-03", 3, "bip39")
+	if err != nil || s.Value != "exoticangryanswer" || s.Hash != "50011c26d0" || s.Period != "2026-02-03" {
+		t.Errorf("got %+v, %v", s, err)
+	}
+	// Version1 derives at most 24 words: longer slugs are an error, not
+	// truncated or extended.
+	for _, length := range []int{25, 0, -1} {
+		if _, err := Derive("seedphrase", "2026-02-03", length, "bip39"); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("length %d: expected ErrInvalidLength, got %v", length, err)
+		}
+	}
+	if s, err := Derive("seedphrase", "2026-02-03", 24, "bip39"); err != nil || len(s.Hash) != 64 {
+		t.Errorf("length 24: got %+v, %v", s, err)
+	}
+	if _, err := Derive("seedphrase", "2026-02-03", 3, "words"); !errors.Is(err, ErrInvalidMode) {
+		t.Errorf("expected ErrInvalidMode, got %v", err)
+	}
+}
+
 func TestGenerate(t *testing.T) {
 	slugs, err := Generate("seedphrase", "2026-02-03", 16, 3, "day", "obfuscated")
 	if err != nil {
@@ -45,41 +72,32 @@ func TestGenerate(t *testing.T) {
 }
 
 func TestGenerateErrors(t *testing.T) {
-	if _, err := Generate("seed", "invalid", 3, 3, "day", "bip39"); err == nil {
-		t.Error("expected error for invalid time")
+	if _, err := Generate("seed", "invalid", 3, 3, "day", "bip39"); !errors.Is(err, ErrInvalidTime) {
+		t.Errorf("expected ErrInvalidTime, got %v", err)
+	}
+	if _, err := Generate("seed", "2026-02-03", 3, 3, "invalid", "bip39"); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("expected ErrInvalidInterval, got %v", err)
 	}
-	if _, err := Generate("seed", "2026-02-03", 3, 3, "invalid", "bip39"); err == nil {
-		t.Error("expected error for invalid interval")
+	if _, err := Generate("seed", "2026-02-03", 3, -1, "day", "bip39"); !errors.Is(err, ErrInvalidWindow) {
+		t.Errorf("expected ErrInvalidWindow, got %v", err)
 	}
 }
 
 func TestParseTime(t *testing.T) {
 	valid := []string{"2026-02-03", "2026-02-03T15", "2026-02-03T15:04", "2026-02-03T15:04:05", "2026-02-03T15:04:05Z"}
 	for _, s := range valid {
-		if _, err := parseTime(s); err != nil {
-			t.Errorf("parseTime(%q) failed: %v", s, err)
+		if _, err := ParseTime(s); err != nil {
+			t.Errorf("ParseTime(%q) failed: %v", s, err)
 		}
 	}
 	invalid := []string{"invalid", "02-03-2026", ""}
 	for _, s := range invalid {
-		if _, err := parseTime(s); err == nil {
-			t.Errorf("parseTime(%q) should fail", s)
+		if _, err := ParseTime(s); err == nil {
+			t.Errorf("ParseTime(%q) should fail", s)
 		}
 	}
 }
 
-func TestParseInterval(t *testing.T) {
-	valid := []string{"s", "second", "m", "minute", "h", "hour", "d", "day", "", "w", "week"}
-	for _, s := range valid {
-		if _, _, err := parseInterval(s); err != nil {
-			t.Errorf("parseInterval(%q) failed: %v", s, err)
-		}
-	}
-	if _, _, err := parseInterval("invalid"); err == nil {
-		t.Error("parseInterval(invalid) should fail")
-	}
-}
-
 func TestShortenWord(t *testing.T) {
 	cases := map[string]string{
 		"the": "the", "cat": "cat", // short unchanged
@@ -136,22 +154,65 @@ func TestBuildObfuscatedSlug(t *testing.T) {
 }
 
 func TestEntropyToBIP39Words(t *testing.T) {
-	words := entropyToBIP39Words(hmacSHA256("seed", "seed:2026-01-01"))
+	words := entropyToBIP39Words(hmacSHA256("seed", "seed:2026-01-01"), wordlists[LanguageEnglish])
 	if len(words) != 24 {
 		t.Errorf("expected 24 words, got %d", len(words))
 	}
 }
 
+func TestDeriveObfuscatedLength(t *testing.T) {
+	cases := []struct {
+		length int
+		slug   string
+	}{
+		{4, "tryb"},
+		{8, "trybeamb"},
+		{16, "trybeambold8node"},
+		{32, "trybeambold8nodecopadrozoomlagri"},
+	}
+	for _, tc := range cases {
+		if slug, _ := derive("seedphrase", "2026-02-03", tc.length, ModeObfuscated, Version2); slug != tc.slug {
+			t.Errorf("length %d: got %q, want %q", tc.length, slug, tc.slug)
+		}
+	}
+
+	for i := 1; i <= 28; i++ {
+		period := fmt.Sprintf("2026-01-%02d", i)
+		for _, length := range []int{1, 7, 40, 100} {
+			slug, _ := derive("seed", period, length, ModeObfuscated, Version2)
+			if len(slug) != length || containsBlockedWord(slug) || strings.HasSuffix(slug, "-") {
+				t.Errorf("%s length %d: got %q", period, length, slug)
+			}
+		}
+	}
+
+	// The consonant replacing a trailing dash is filtered like any chunk,
+	// so it does not form a triple letter (gammm).
+	if slug, _ := derive("seedphrase", "2026-02-03T20810", 5, ModeObfuscated, Version2); slug != "gammj" {
+		t.Errorf("trailing dash: got %q", slug)
+	}
+
+	// Long slugs are built in linear time.
+	if slug, _ := derive("seedphrase", "2026-02-03", 100000, ModeObfuscated, Version2); len(slug) != 100000 || removeTripleLetters(slug) != slug {
+		t.Errorf("length 100000: got %d characters", len(slug))
+	}
+
+	// Version1 ignores length.
+	if slug, _ := derive("seedphrase", "2026-02-03", 32, ModeObfuscated, Version1); slug != "trybeambold8" {
+		t.Errorf("Version1: got %q", slug)
+	}
+}
+
 func TestBIP39WordlistLoaded(t *testing.T) {
-	if len(bip39Words) != 2048 {
-		t.Errorf("expected 2048 BIP39 words, got %d", len(bip39Words))
+	if len(wordlists[LanguageEnglish]) != 2048 {
+		t.Errorf("expected 2048 BIP39 words, got %d", len(wordlists[LanguageEnglish]))
 	}
 }
 
 func TestSlugUniqueness(t *testing.T) {
 	seen := make(map[string]bool)
 	for i := 1; i <= 28; i++ {
-		slug, _ := derive("seed", fmt.Sprintf("2026-01-%02d", i), 3, "bip39")
+		slug, _ := derive("seed", fmt.Sprintf("2026-01-%02d", i), 3, "bip39", Version1)
 		if seen[slug] {
 			t.Errorf("collision for slug %q", slug)
 		}
@@ -161,6 +222,6 @@ func TestSlugUniqueness(t *testing.T) {
 
 func BenchmarkDerive(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		derive("seedphrase", "2026-02-03", 3, "bip39")
+		derive("seedphrase", "2026-02-03", 3, "bip39", Version1)
 	}
 }
@@ -0,0 +1,73 @@
+package timeslug
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNoMatch          = errors.New("slug does not match any period")
+	ErrInvalidTolerance = errors.New("invalid tolerance")
+)
+
+// Match is the period a verified slug belongs to.
+type Match struct {
+	Period string
+	// Offset is the position of Period relative to the period containing
+	// the verification time: -1 for the previous period, 0 for the current
+	// one, 1 for the next one, and so on.
+	Offset int
+	// SeedID is the ID of the scheduled seed the slug was derived with, or
+	// empty without WithSeeds.
+	SeedID string
+}
+
+// Verify checks whether slug belongs to the period containing now or to one
+// of the tolerance periods before or after it. It returns ErrNoMatch if it
+// does not.
+func Verify(seed, slug string, now time.Time, tolerance int, interval, mode string, length int) (Match, error) {
+	g, err := New(seed, WithLength(length), WithInterval(interval), WithMode(mode))
+	if err != nil {
+		return Match{}, err
+	}
+	return g.Verify(slug, now, tolerance)
+}
+
+// Verify checks whether slug belongs to the period containing now or to one
+// of the tolerance periods before or after it, derived with any seed valid
+// for that period. Every candidate is compared in constant time, so the
+// time taken does not reveal which period or seed, if any, matched. It
+// returns ErrNoMatch if none did.
+func (g *Generator) Verify(slug string, now time.Time, tolerance int) (Match, error) {
+	if tolerance < 0 {
+		return Match{}, fmt.Errorf("%w: %d", ErrInvalidTolerance, tolerance)
+	}
+
+	var match Match
+	found := 0
+	for i := range 2*tolerance + 1 {
+		// 0, -1, 1, -2, 2, ...: closest periods first, so a collision
+		// resolves to the nearest one.
+		offset := (i + 1) / 2
+		if i%2 == 1 {
+			offset = -offset
+		}
+		t := g.iv.add(now.In(g.loc), offset)
+		period := g.iv.period(t)
+		// The seed deriving new slugs first, then seeds in their grace period.
+		for _, s := range g.activeSeeds(g.iv.begin(t)) {
+			candidate := g.deriveWith(s, period)
+			equal := subtle.ConstantTimeCompare([]byte(candidate.Value), []byte(slug))
+			if equal&^found == 1 {
+				match = Match{Period: period, Offset: offset, SeedID: s.ID}
+			}
+			found |= equal
+		}
+	}
+	if found == 0 {
+		return Match{}, ErrNoMatch
+	}
+	return match, nil
+}
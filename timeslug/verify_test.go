@@ -0,0 +1,56 @@
+package timeslug
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2026, 2, 4, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		slug   string
+		period string
+		offset int
+	}{
+		{"trybeambold8", "2026-02-03", -1},
+		{"brightbeamvivar", "2026-02-04", 0},
+		{"trycorefastfum", "2026-02-05", 1},
+	}
+	for _, tc := range cases {
+		m, err := Verify("seedphrase", tc.slug, now, 1, "day", "obfuscated", 16)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.slug, err)
+		}
+		if m.Period != tc.period || m.Offset != tc.offset {
+			t.Errorf("%s: got %+v, want %s/%d", tc.slug, m, tc.period, tc.offset)
+		}
+	}
+
+	// Outside tolerance
+	if _, err := Verify("seedphrase", "trybeambold8", now, 0, "day", "obfuscated", 16); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch, got %v", err)
+	}
+	if _, err := Verify("seedphrase", "", now, 1, "day", "obfuscated", 16); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch for empty slug, got %v", err)
+	}
+	if _, err := Verify("other", "brightbeamvivar", now, 3, "day", "obfuscated", 16); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch for wrong seed, got %v", err)
+	}
+
+	// Errors
+	if _, err := Verify("seedphrase", "x", now, -1, "day", "bip39", 3); !errors.Is(err, ErrInvalidTolerance) {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+	if _, err := Verify("seedphrase", "x", now, 1, "invalid", "bip39", 3); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("expected ErrInvalidInterval, got %v", err)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	g, _ := New("seedphrase")
+	now := time.Date(2026, 2, 4, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		g.Verify("exoticangryanswer", now, 1)
+	}
+}
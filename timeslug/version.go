@@ -0,0 +1,188 @@
+package timeslug
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Algorithm versions. Slugs of a version never change; generation changes
+// ship as a new version.
+const (
+	// Version1 is the original algorithm. Obfuscated slugs are 10 to 18
+	// characters regardless of length.
+	Version1 = 1
+	// Version2 makes obfuscated slugs exactly length characters and BIP39
+	// slugs longer than 24 words.
+	Version2 = 2
+	// Version3 derives slugs like Version2 from HKDF subkeys bound to their
+	// purpose, mode and namespace, and the verification hash from a subkey
+	// separate from the slug.
+	Version3 = 3
+)
+
+// hkdfSalt is the HKDF salt of Version3.
+const hkdfSalt = "timeslug/v3"
+
+// params are the settings a slug is derived with besides seed and period.
+type params struct {
+	length int
+	mode   string
+	// namespace separates the slugs of several services sharing a seed.
+	// Only Version3 derives with it; it must not contain NUL.
+	namespace string
+	// words is the BIP39 wordlist, or a custom wordlist if custom is set.
+	// The words of a slug are cased by wordCase and joined by separator.
+	words     []string
+	custom    bool
+	separator string
+	wordCase  string
+}
+
+// algorithm derives the slug value and verification hash of a period.
+type algorithm struct {
+	derive func(seed, period string, p params) (string, string)
+	// maxWords is the largest number of BIP39 words the algorithm derives,
+	// or 0 for no limit.
+	maxWords int
+	// namespaces is set if the algorithm derives with params.namespace.
+	namespaces bool
+}
+
+// algorithms registers every released version. A registered algorithm is
+// frozen together with the word lists it uses: changing its output would
+// rotate every slug derived with it.
+var algorithms = map[int]algorithm{
+	Version1: {derive: deriveV1, maxWords: 24},
+	Version2: {derive: deriveV2},
+	Version3: {derive: deriveV3, namespaces: true},
+}
+
+// Versions returns the supported algorithm versions in ascending order.
+func Versions() []int {
+	return slices.Sorted(maps.Keys(algorithms))
+}
+
+func lookupAlgorithm(version, length int) (algorithm, error) {
+	alg, ok := algorithms[version]
+	if !ok {
+		return algorithm{}, fmt.Errorf("%w: %d", ErrInvalidVersion, version)
+	}
+	if length < 1 {
+		return algorithm{}, fmt.Errorf("%w: %d", ErrInvalidLength, length)
+	}
+	return alg, nil
+}
+
+func deriveV1(seed, period string, p params) (string, string) {
+	entropy := hmacSHA256(seed, seed+":"+period)
+	if strings.EqualFold(p.mode, ModeObfuscated) {
+		return buildObfuscatedSlug(entropy), obfuscatedHash(seed, period, p.length)
+	}
+	return wordSlug(seed, period, entropy, p)
+}
+
+// deriveV2 differs from deriveV1 only in obfuscated slugs, which are
+// extended from further entropy blocks to exactly length characters. BIP39
+// slugs of more than 24 words, which Version1 rejects, use the same word
+// derivation.
+func deriveV2(seed, period string, p params) (string, string) {
+	entropy := hmacSHA256(seed, seed+":"+period)
+	if strings.EqualFold(p.mode, ModeObfuscated) {
+		slug := buildObfuscatedSlugLength(newEntropyStream(seed, period, entropy), p.length)
+		return slug, obfuscatedHash(seed, period, p.length)
+	}
+	return wordSlug(seed, period, entropy, p)
+}
+
+// deriveV3 derives the slug from the HKDF-Expand stream of the slug subkey
+// and the hash, of the same length as in Version2, from the hash subkey. The
+// seed is only the HKDF input key, never part of a message.
+func deriveV3(seed, period string, p params) (string, string) {
+	prk, _ := hkdf.Extract(sha256.New, []byte(seed), []byte(hkdfSalt))
+	mode := ModeBIP39
+	if strings.EqualFold(p.mode, ModeObfuscated) {
+		mode = ModeObfuscated
+	}
+	e := newHKDFStream(prk, hkdfInfo("slug", mode, p.namespace, period))
+	var slug string
+	hashLen := 0
+	switch {
+	case mode == ModeObfuscated:
+		slug = buildObfuscatedSlugLength(e, p.length)
+		hashLen = min((p.length+1)/2, 16)
+	case p.custom:
+		var hash string
+		slug, hash = customSlug(e, p)
+		hashLen = len(hash) / 2
+	default:
+		var hash string
+		slug, hash = bip39Slug(e, p)
+		hashLen = len(hash) / 2
+	}
+	hash, _ := hkdf.Expand(sha256.New, prk, string(hkdfInfo("hash", mode, p.namespace, period)), hashLen)
+	return slug, hex.EncodeToString(hash)
+}
+
+// hkdfInfo is the HKDF info of a Version3 subkey: the context labels joined
+// by NUL bytes, none of which contains NUL.
+func hkdfInfo(purpose, mode, namespace, period string) []byte {
+	return []byte(strings.Join([]string{hkdfSalt, purpose, mode, namespace, period}, "\x00"))
+}
+
+// wordSlug joins words of the BIP39 wordlist or of a custom wordlist.
+func wordSlug(seed, period string, entropy []byte, p params) (string, string) {
+	e := newEntropyStream(seed, period, entropy)
+	if p.custom {
+		return customSlug(e, p)
+	}
+	return bip39Slug(e, p)
+}
+
+// bip39Slug joins the first length words of the mnemonics of consecutive
+// entropy blocks, 24 words per block. The hash is at most the 32 bytes of
+// the first block.
+func bip39Slug(e *entropyStream, p params) (string, string) {
+	var words []string
+	for i := 0; len(words) < p.length; i++ {
+		words = append(words, entropyToBIP39Words(e.block(i), p.words)...)
+	}
+	hashLen := min((p.length*11+7)/8, len(e.block(0)))
+	return joinWords(words[:p.length], p), hex.EncodeToString(e.block(0)[:hashLen])
+}
+
+// obfuscatedHash is the verification hash of obfuscated slugs, which is
+// independent of the slug value.
+func obfuscatedHash(seed, period string, length int) string {
+	hash := hmacSHA256(seed, seed+":skid:"+period)
+	hashLen := min((length+1)/2, 16)
+	return hex.EncodeToString(hash[:hashLen])
+}
+
+// joinWords cases the words of a slug and joins them with the separator.
+// Without a case, words are kept as given.
+func joinWords(words []string, p params) string {
+	cased := make([]string, len(words))
+	for i, w := range words {
+		switch p.wordCase {
+		case CaseLower:
+			w = strings.ToLower(w)
+		case CaseUpper:
+			w = strings.ToUpper(w)
+		case CaseTitle, CaseCamel:
+			w = strings.ToLower(w)
+			if p.wordCase == CaseTitle || i > 0 {
+				r, size := utf8.DecodeRuneInString(w)
+				w = string(unicode.ToUpper(r)) + w[size:]
+			}
+		}
+		cased[i] = w
+	}
+	return strings.Join(cased, p.separator)
+}
@@ -0,0 +1,87 @@
+package timeslug
+
+import (
+	"bytes"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// Reference test vectors per algorithm version - must match all
+// implementations (Python, Java, C++). Vectors of a released version must
+// never change.
+var versionTestVectors = []struct {
+	version            int
+	seed, period, mode string
+	length             int
+	slug, hash         string
+}{
+	{Version1, "seedphrase", "2026-02-03", "obfuscated", 32, "trybeambold8", "5d3bf0d55db67ea20078c1e8bf5cbaa7"},
+	{Version1, "seedphrase", "2026-02-04", "bip39", 4, "policekitchencomicdecember", "a7af60b91c52"},
+	{Version1, "seedphrase", "2026-02-03", "bip39", 24, "exoticangryanswerpatternmainislandcousinartefactfireshieldvesseliceadmitcattleatomdrasticcausecriticgrantattitudemagnetbodytonegallery", "50011c26d0a864eccc58675738b7cc38103a488392122486799687585e31f912"},
+	{Version2, "seedphrase", "2026-02-03", "obfuscated", 16, "trybeambold8node", "5d3bf0d55db67ea2"},
+	{Version2, "seedphrase", "2026-02-04", "obfuscated", 12, "brightbeamvi", "f9fb66a05050"},
+	{Version2, "seedphrase", "2026-02-05", "obfuscated", 24, "trycorefastfumpixelbeice", "8bb68bd056e4a6ff3d023c47"},
+	{Version2, "seedphrase", "2026-02-03T12:15/15m", "obfuscated", 16, "pen-dotpaxmegago", "f652cdf8dc97eaf5"},
+	{Version2, "seedphrase", "2026-02-04", "bip39", 4, "policekitchencomicdecember", "a7af60b91c52"},
+	{Version2, "seedphrase", "2026-02-03", "bip39", 26, "exoticangryanswerpatternmainislandcousinartefactfireshieldvesseliceadmitcattleatomdrasticcausecriticgrantattitudemagnetbodytonegalleryassumeprotect", "50011c26d0a864eccc58675738b7cc38103a488392122486799687585e31f912"},
+	{Version3, "seedphrase", "2026-02-03", "obfuscated", 16, "podsynclynodedoc", "392c5eaa99267d7e"},
+	{Version3, "seedphrase", "2026-02-04", "obfuscated", 12, "techmomintva", "9d0c143db089"},
+	{Version3, "seedphrase", "2026-02-03T12:15/15m", "obfuscated", 16, "lionbeamcopopzux", "5ae1dd939a5b15c7"},
+	{Version3, "seedphrase", "2026-02-03", "bip39", 3, "mythmarriagevirus", "c2923898e3"},
+	{Version3, "seedphrase", "2026-02-04", "bip39", 4, "odoranotherquickportion", "69e513075276"},
+	{Version3, "seedphrase", "2026-02-03", "bip39", 26, "mythmarriagevirushobbypluckgorillachestwastedoctorlibrarywearmaindigitaltargetaugustdisagreepredictsixpridestrugglehandstrategyofficedollquickmethod", "c2923898e36be69607be91efedcafa85b906fe07cfb11cfa2c1d18810ae46ff8"},
+}
+
+func TestVersionVectors(t *testing.T) {
+	for _, tc := range versionTestVectors {
+		slug, hash := derive(tc.seed, tc.period, tc.length, tc.mode, tc.version)
+		if slug != tc.slug || hash != tc.hash {
+			t.Errorf("v%d %s/%s: got %q/%q, want %q/%q", tc.version, tc.period, tc.mode, slug, hash, tc.slug, tc.hash)
+		}
+	}
+}
+
+func TestVersions(t *testing.T) {
+	if got := Versions(); !slices.Equal(got, []int{Version1, Version2, Version3}) {
+		t.Errorf("got %v", got)
+	}
+	for _, v := range Versions() {
+		if _, err := lookupAlgorithm(v, 3); err != nil {
+			t.Errorf("v%d: %v", v, err)
+		}
+	}
+	if _, err := lookupAlgorithm(99, 3); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("expected ErrInvalidVersion, got %v", err)
+	}
+	for _, v := range Versions() {
+		if _, err := lookupAlgorithm(v, 0); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("v%d: expected ErrInvalidLength, got %v", v, err)
+		}
+	}
+}
+
+func TestHKDFStream(t *testing.T) {
+	prk, err := hkdf.Extract(sha256.New, []byte("seedphrase"), []byte(hkdfSalt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := hkdfInfo("slug", ModeBIP39, "", "2026-02-03")
+	want, err := hkdf.Expand(sha256.New, prk, string(info), 3*32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := newHKDFStream(prk, info)
+	if got := slices.Concat(e.block(0), e.block(1), e.block(2)); !bytes.Equal(got, want) {
+		t.Errorf("stream differs from HKDF-Expand:\n%x\n%x", got, want)
+	}
+
+	// The slug and hash subkeys differ, and so do the modes.
+	hash, _ := hkdf.Expand(sha256.New, prk, string(hkdfInfo("hash", ModeBIP39, "", "2026-02-03")), 32)
+	obfuscated, _ := hkdf.Expand(sha256.New, prk, string(hkdfInfo("slug", ModeObfuscated, "", "2026-02-03")), 32)
+	if bytes.Equal(hash, want[:32]) || bytes.Equal(obfuscated, want[:32]) {
+		t.Error("expected separate subkeys")
+	}
+}
@@ -0,0 +1,167 @@
+package timeslug
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"math/bits"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// BIP39 wordlist languages: every official list except Portuguese, which
+// is not embedded yet.
+const (
+	LanguageChineseSimplified  = "chinese_simplified"
+	LanguageChineseTraditional = "chinese_traditional"
+	LanguageCzech              = "czech"
+	LanguageEnglish            = "english"
+	LanguageFrench             = "french"
+	LanguageItalian            = "italian"
+	LanguageJapanese           = "japanese"
+	LanguageKorean             = "korean"
+	LanguageSpanish            = "spanish"
+)
+
+// Official BIP39 wordlists, one bip39_<language>.txt file per language.
+// Adding a file adds the language.
+//
+//go:embed bip39_*.txt
+var wordlistFiles embed.FS
+
+// wordlists holds the NFKD normalized wordlist of each language.
+var wordlists = map[string][]string{}
+
+func init() {
+	files, _ := wordlistFiles.ReadDir(".")
+	for _, f := range files {
+		data, err := wordlistFiles.ReadFile(f.Name())
+		if err != nil {
+			panic(err)
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(f.Name(), "bip39_"), path.Ext(f.Name()))
+		words := strings.Split(strings.TrimSpace(norm.NFKD.String(string(data))), "\n")
+		if len(words) != 2048 {
+			panic(fmt.Sprintf("timeslug: %s wordlist has %d words", name, len(words)))
+		}
+		wordlists[name] = words
+	}
+}
+
+// Languages returns the embedded BIP39 wordlist languages in alphabetical
+// order.
+func Languages() []string {
+	return slices.Sorted(maps.Keys(wordlists))
+}
+
+// DefaultSeparator returns the string joining the words of BIP39 slugs in
+// language without WithSeparator: the ideographic space for Japanese, as in
+// Japanese BIP39 mnemonics, and none otherwise.
+func DefaultSeparator(language string) string {
+	if strings.EqualFold(language, LanguageJapanese) {
+		return "\u3000"
+	}
+	return ""
+}
+
+func lookupWordlist(language string) ([]string, error) {
+	words, ok := wordlists[strings.ToLower(language)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLanguage, language)
+	}
+	return words, nil
+}
+
+// ParseWordlist returns the words of a custom wordlist, one per line.
+// Blank lines and lines starting with # are skipped.
+func ParseWordlist(s string) []string {
+	var words []string
+	for line := range strings.Lines(s) {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words
+}
+
+// LoadWordlist reads a custom wordlist file in the format of ParseWordlist.
+func LoadWordlist(name string) ([]string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWordlist, err)
+	}
+	return ParseWordlist(string(data)), nil
+}
+
+// checkWordlist returns the NFKD normalized words of a custom wordlist. It
+// needs at least two distinct, non-empty words.
+func checkWordlist(words []string) ([]string, error) {
+	if len(words) < 2 {
+		return nil, fmt.Errorf("%w: %d words, need at least 2", ErrInvalidWordlist, len(words))
+	}
+	normalized := make([]string, len(words))
+	seen := make(map[string]bool, len(words))
+	for i, w := range words {
+		w = norm.NFKD.String(strings.TrimSpace(w))
+		if w == "" {
+			return nil, fmt.Errorf("%w: empty word at index %d", ErrInvalidWordlist, i)
+		}
+		if seen[w] {
+			return nil, fmt.Errorf("%w: duplicate word %q", ErrInvalidWordlist, w)
+		}
+		seen[w] = true
+		normalized[i] = w
+	}
+	return normalized, nil
+}
+
+// WordlistDigest returns a short SHA-256 digest of a wordlist, which changes
+// whenever a word is added, removed, changed or reordered.
+func WordlistDigest(words []string) string {
+	h := sha256.New()
+	for _, w := range words {
+		h.Write([]byte(norm.NFKD.String(strings.TrimSpace(w))))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// customSlug joins length words of a custom wordlist. Each index is read
+// from the next bits.Len(n-1) bits of the entropy stream, and indices not
+// below the list size n are skipped, so every word is equally likely. For
+// lists of a power of two words no index is skipped.
+func customSlug(e *entropyStream, p params) (string, string) {
+	n := len(p.words)
+	size := bits.Len(uint(n - 1))
+	r := bitReader{e: e}
+	words := make([]string, 0, p.length)
+	for len(words) < p.length {
+		if i := r.read(size); i < n {
+			words = append(words, p.words[i])
+		}
+	}
+	hashLen := min((p.length*size+7)/8, len(e.block(0)))
+	return joinWords(words, p), hex.EncodeToString(e.block(0)[:hashLen])
+}
+
+// bitReader reads an entropy stream bit by bit, most significant bit first.
+type bitReader struct {
+	e   *entropyStream
+	pos int
+}
+
+func (r *bitReader) read(n int) int {
+	v := 0
+	for range n {
+		b := r.e.block(r.pos / 256)[r.pos%256/8]
+		v = v<<1 | int(b>>(7-r.pos%8)&1)
+		r.pos++
+	}
+	return v
+}
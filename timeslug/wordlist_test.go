@@ -0,0 +1,146 @@
+package timeslug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestWordlists(t *testing.T) {
+	if !slices.Contains(Languages(), LanguageEnglish) {
+		t.Fatalf("missing %s in %v", LanguageEnglish, Languages())
+	}
+	for _, language := range Languages() {
+		words := wordlists[language]
+		seen := make(map[string]bool, len(words))
+		for _, w := range words {
+			if seen[w] {
+				t.Errorf("%s: duplicate word %q", language, w)
+			}
+			seen[w] = true
+			if !norm.NFKD.IsNormalString(w) {
+				t.Errorf("%s: %q is not NFKD normalized", language, w)
+			}
+		}
+	}
+
+	if words, err := lookupWordlist("English"); err != nil || words[0] != "abandon" {
+		t.Errorf("got %v, %v", words[:1], err)
+	}
+	if _, err := lookupWordlist("klingon"); !errors.Is(err, ErrInvalidLanguage) {
+		t.Errorf("expected ErrInvalidLanguage, got %v", err)
+	}
+}
+
+func TestParseWordlist(t *testing.T) {
+	got := ParseWordlist("# brand-safe words\nred\n\n  green \r\nblue\n")
+	if !slices.Equal(got, []string{"red", "green", "blue"}) {
+		t.Errorf("got %q", got)
+	}
+
+	name := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(name, []byte("red\ngreen\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := LoadWordlist(name); err != nil || !slices.Equal(got, []string{"red", "green"}) {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if _, err := LoadWordlist(filepath.Join(t.TempDir(), "missing.txt")); !errors.Is(err, ErrInvalidWordlist) {
+		t.Errorf("expected ErrInvalidWordlist, got %v", err)
+	}
+}
+
+func TestCheckWordlist(t *testing.T) {
+	invalid := map[string][]string{
+		"empty list":     nil,
+		"single word":    {"red"},
+		"empty word":     {"red", " "},
+		"duplicate":      {"red", "green", "red"},
+		"NFKD duplicate": {"caf\u00e9", "cafe\u0301"},
+	}
+	for name, words := range invalid {
+		if _, err := checkWordlist(words); !errors.Is(err, ErrInvalidWordlist) {
+			t.Errorf("%s: expected ErrInvalidWordlist, got %v", name, err)
+		}
+	}
+	if words, err := checkWordlist([]string{" red", "caf\u00e9"}); err != nil || !slices.Equal(words, []string{"red", "cafe\u0301"}) {
+		t.Errorf("got %q, %v", words, err)
+	}
+}
+
+func TestWordlistDigest(t *testing.T) {
+	d := WordlistDigest([]string{"red", "green", "blue"})
+	if len(d) != 16 {
+		t.Errorf("got %q", d)
+	}
+	if WordlistDigest([]string{"red", "blue", "green"}) == d || WordlistDigest([]string{"red", "green"}) == d {
+		t.Error("digest does not change with the list")
+	}
+	if WordlistDigest([]string{" red", "green", "blue"}) != d {
+		t.Error("digest changes with surrounding space")
+	}
+}
+
+func TestCustomWordlist(t *testing.T) {
+	cases := []struct {
+		words              []string
+		slug, hash, digest string
+	}{
+		// Power of two: 2 bits per word, no index skipped.
+		{[]string{"red", "green", "blue", "gold"}, "green-green-red-red", "50", "480591bfe8fa04e5"},
+		// Arbitrary size: index 3 is skipped.
+		{[]string{"alpha", "beta", "gamma"}, "beta-beta-alpha-alpha", "50", "4fdbc441ea7b5461"},
+	}
+	for _, tc := range cases {
+		g, err := New("seedphrase", WithWordlist(tc.words), WithLength(4), WithSeparator("-"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := g.Derive("2026-02-03"); s.Value != tc.slug || s.Hash != tc.hash {
+			t.Errorf("%v: got %q/%q, want %q/%q", tc.words, s.Value, s.Hash, tc.slug, tc.hash)
+		}
+		if got := g.WordlistDigest(); got != tc.digest {
+			t.Errorf("%v: digest %q, want %q", tc.words, got, tc.digest)
+		}
+	}
+
+	// Long slugs read past the first entropy block.
+	g, err := New("seedphrase", WithWordlist([]string{"a", "b", "c"}), WithLength(MaxWords))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Derive("2026-02-03").Value; len(got) != MaxWords {
+		t.Errorf("got %d words", len(got))
+	}
+
+	// Skipping out of range indices keeps the words of a 3-word list
+	// equally likely.
+	g, err = New("seed", WithWordlist([]string{"a", "b", "c"}), WithLength(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]int{}
+	for i := range 3000 {
+		counts[g.Derive(fmt.Sprint(i)).Value]++
+	}
+	for w, n := range counts {
+		if n < 900 || n > 1100 {
+			t.Errorf("%s: %d of 3000", w, n)
+		}
+	}
+
+	if g, _ := New("seedphrase"); g.WordlistDigest() != "" {
+		t.Errorf("BIP39 digest %q", g.WordlistDigest())
+	}
+	if _, err := New("seed", WithWordlist([]string{"a", "a"})); !errors.Is(err, ErrInvalidWordlist) {
+		t.Errorf("expected ErrInvalidWordlist, got %v", err)
+	}
+	if _, err := New("seed", WithWordlist([]string{"a", "b"}), WithLength(0)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("expected ErrInvalidLength, got %v", err)
+	}
+}